@@ -0,0 +1,197 @@
+package asyncapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/schema"
+	"gopkg.in/yaml.v2"
+)
+
+// Version of generated documents
+const Version = "3.0.0"
+
+const refPrefix = "#/components/schemas/"
+
+var invalidIDChar = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// Document AsyncAPI 3.0 document with a channel and receive operation per route
+type Document struct {
+	AsyncAPI   string                `json:"asyncapi" yaml:"asyncapi"`
+	Info       Info                  `json:"info" yaml:"info"`
+	Channels   map[string]*Channel   `json:"channels" yaml:"channels"`
+	Operations map[string]*Operation `json:"operations" yaml:"operations"`
+	Components *Components           `json:"components,omitempty" yaml:"components,omitempty"`
+}
+
+// Info about the application
+type Info struct {
+	Title       string `json:"title" yaml:"title"`
+	Version     string `json:"version" yaml:"version"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// Channel events are consumed from, the address is the route key
+type Channel struct {
+	Address     string              `json:"address" yaml:"address"`
+	Description string              `json:"description,omitempty" yaml:"description,omitempty"`
+	Messages    map[string]*Message `json:"messages" yaml:"messages"`
+	// Tags name the channel's source, e.g. sns
+	Tags []Tag `json:"tags" yaml:"tags"`
+}
+
+// Message consumed from a channel, Payload is set for typed routes
+type Message struct {
+	Name        string         `json:"name" yaml:"name"`
+	Summary     string         `json:"summary,omitempty" yaml:"summary,omitempty"`
+	ContentType string         `json:"contentType,omitempty" yaml:"contentType,omitempty"`
+	Payload     *schema.Schema `json:"payload,omitempty" yaml:"payload,omitempty"`
+}
+
+// Operation on a channel, always receive as routes only consume events
+type Operation struct {
+	Action   string `json:"action" yaml:"action"`
+	Channel  Ref    `json:"channel" yaml:"channel"`
+	Messages []Ref  `json:"messages" yaml:"messages"`
+}
+
+// Ref reference to a channel or message
+type Ref struct {
+	Ref string `json:"$ref" yaml:"$ref"`
+}
+
+// Tag on a channel
+type Tag struct {
+	Name string `json:"name" yaml:"name"`
+}
+
+// Components shared by messages
+type Components struct {
+	Schemas map[string]*schema.Schema `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+}
+
+// Config for document generation
+type Config struct {
+	Info Info
+}
+
+// Generate document from routes as listed by router.Event.Routes(). HTTP and authorizer
+// routes are left out, see openapi.Generate
+func Generate(routes []domain.RouteInfo, config Config) *Document {
+	reflector := schema.NewReflector(refPrefix)
+	doc := &Document{
+		AsyncAPI:   Version,
+		Info:       config.Info,
+		Channels:   map[string]*Channel{},
+		Operations: map[string]*Operation{},
+	}
+
+	for _, route := range routes {
+		description, ok := descriptions[route.Source]
+		if !ok {
+			continue
+		}
+
+		id := channelID(route, doc.Channels)
+		message := &Message{Name: messageName(route), Summary: description.message}
+		if route.Payload != nil {
+			message.ContentType = "application/json"
+			message.Payload = reflector.Reflect(route.Payload)
+		}
+		doc.Channels[id] = &Channel{
+			Address:     route.Key,
+			Description: description.channel,
+			Messages:    map[string]*Message{message.Name: message},
+			Tags:        []Tag{{Name: route.Source}},
+		}
+		doc.Operations[id] = &Operation{
+			Action:   "receive",
+			Channel:  Ref{Ref: "#/channels/" + id},
+			Messages: []Ref{{Ref: "#/channels/" + id + "/messages/" + message.Name}},
+		}
+	}
+
+	if len(reflector.Definitions) > 0 {
+		doc.Components = &Components{Schemas: reflector.Definitions}
+	}
+	return doc
+}
+
+// JSON encoded document
+func (d *Document) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
+
+// YAML encoded document
+func (d *Document) YAML() ([]byte, error) {
+	return yaml.Marshal(d)
+}
+
+type description struct {
+	channel string
+	message string
+}
+
+// Descriptions of documented sources
+var descriptions = map[string]description{
+	domain.SourceSNS: {
+		channel: "SNS topic",
+		message: "JSON message published to the topic",
+	},
+	domain.SourceDynamoDB: {
+		channel: "DynamoDB stream",
+		message: "Old and new images of a changed item",
+	},
+	domain.SourceS3: {
+		channel: "S3 object key prefix",
+		message: "S3 event notification for an object under the prefix",
+	},
+	domain.SourceSchedule: {
+		channel: "EventBridge schedule rule",
+		message: "Scheduled event",
+	},
+}
+
+// Channel id from source and the name within the route key, e.g. sns.orders-created for
+// arn:aws:sns:eu-west-1:123456789012:orders-created
+func channelID(route domain.RouteInfo, taken map[string]*Channel) string {
+	name := route.Key
+	switch route.Source {
+	case domain.SourceDynamoDB:
+		if idx := strings.Index(name, ":table/"); idx >= 0 {
+			name = strings.Split(name[idx+len(":table/"):], "/")[0]
+		}
+	case domain.SourceS3:
+		name = strings.ReplaceAll(strings.Trim(name, "/"), "/", ".")
+	default:
+		name = name[strings.LastIndexAny(name, ":/")+1:]
+	}
+	name = strings.Trim(invalidIDChar.ReplaceAllString(name, "_"), "_.")
+	if name == "" {
+		name = "root"
+	}
+
+	id := route.Source + "." + name
+	for n := 2; taken[id] != nil; n++ {
+		id = route.Source + "." + name + strconv.Itoa(n)
+	}
+	return id
+}
+
+// Message named after the payload type, or the source for untyped routes
+func messageName(route domain.RouteInfo) string {
+	if route.Payload != nil {
+		t := reflect.TypeOf(route.Payload)
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if name := invalidIDChar.ReplaceAllString(t.Name(), "_"); name != "" {
+			return name
+		}
+	}
+	return route.Source + "Event"
+}
@@ -0,0 +1,56 @@
+package authorizer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MethodARN of an API Gateway method, arn:aws:execute-api:{region}:{account}:{apiId}/{stage}/{method}/{resource}
+type MethodARN struct {
+	Partition string
+	Region    string
+	AccountID string
+	APIID     string
+	Stage     string
+	Method    string
+	Resource  string
+}
+
+// ParseMethodARN from methodArn or routeArn
+func ParseMethodARN(arn string) (*MethodARN, error) {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" || parts[2] != "execute-api" {
+		return nil, fmt.Errorf("invalid method ARN %q", arn)
+	}
+
+	path := strings.SplitN(parts[5], "/", 4)
+	if len(path) < 3 {
+		return nil, fmt.Errorf("invalid method ARN %q", arn)
+	}
+	m := &MethodARN{
+		Partition: parts[1],
+		Region:    parts[3],
+		AccountID: parts[4],
+		APIID:     path[0],
+		Stage:     path[1],
+		Method:    path[2],
+	}
+	if len(path) == 4 {
+		m.Resource = path[3]
+	}
+	return m, nil
+}
+
+// String formatted ARN
+func (m *MethodARN) String() string {
+	return fmt.Sprintf("arn:%s:execute-api:%s:%s:%s/%s/%s/%s",
+		m.Partition, m.Region, m.AccountID, m.APIID, m.Stage, m.Method, strings.TrimPrefix(m.Resource, "/"))
+}
+
+// With method and resource replaced, use * as wildcard, e.g. With("*", "*") for the whole stage
+func (m *MethodARN) With(method string, resource string) string {
+	out := *m
+	out.Method = method
+	out.Resource = resource
+	return out.String()
+}
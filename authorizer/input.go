@@ -0,0 +1,113 @@
+package authorizer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/logging"
+)
+
+// Input for parsed authorizer event
+type Input struct {
+	event  map[string]interface{}
+	logger domain.Logger
+	ctx    context.Context
+}
+
+// NewInput initializer
+func NewInput(e map[string]interface{}) *Input {
+	return &Input{event: e, logger: logging.Default(), ctx: context.Background()}
+}
+
+// Context for current invocation
+func (i *Input) Context() context.Context {
+	return i.ctx
+}
+
+// Logger for current event
+func (i *Input) Logger() domain.Logger {
+	return i.logger
+}
+
+// Type of authorizer event, TypeToken or TypeRequest
+func (i *Input) Type() string {
+	t, _ := i.event["type"].(string)
+	return t
+}
+
+// Token from authorizationToken for TOKEN events, Authorization header or first identity
+// source for REQUEST events. A Bearer prefix is stripped
+func (i *Input) Token() string {
+	token, _ := i.event["authorizationToken"].(string)
+	if token == "" {
+		token = i.GetHeader("Authorization")
+	}
+	if sources, ok := i.event["identitySource"].([]interface{}); ok && token == "" && len(sources) > 0 {
+		token, _ = sources[0].(string)
+	}
+	if len(token) > 7 && strings.EqualFold(token[:7], "Bearer ") {
+		return strings.TrimSpace(token[7:])
+	}
+	return token
+}
+
+// MethodARN of invoked method, routeArn for HTTP API payload version 2.0
+func (i *Input) MethodARN() string {
+	if arn, ok := i.event["methodArn"].(string); ok {
+		return arn
+	}
+	arn, _ := i.event["routeArn"].(string)
+	return arn
+}
+
+// ParsedMethodARN of invoked method
+func (i *Input) ParsedMethodARN() (*MethodARN, error) {
+	return ParseMethodARN(i.MethodARN())
+}
+
+// GetHeader case-insensitively, REQUEST events only
+func (i *Input) GetHeader(header string) string {
+	return lookup(i.event["headers"], header, true)
+}
+
+// GetPathParam in current request, REQUEST events only
+func (i *Input) GetPathParam(param string) string {
+	return lookup(i.event["pathParameters"], param, false)
+}
+
+// GetQueryParam in current request, REQUEST events only
+func (i *Input) GetQueryParam(param string) string {
+	return lookup(i.event["queryStringParameters"], param, false)
+}
+
+// GetStageVariable in current request, REQUEST events only
+func (i *Input) GetStageVariable(name string) string {
+	return lookup(i.event["stageVariables"], name, false)
+}
+
+// CorrelationID from X-Correlation-Id header, falls back to request id
+func (i *Input) CorrelationID() string {
+	if id := i.GetHeader(domain.CorrelationIDHeader); id != "" {
+		return id
+	}
+	reqContext, _ := i.event["requestContext"].(map[string]interface{})
+	id, _ := reqContext["requestId"].(string)
+	return id
+}
+
+func lookup(values interface{}, key string, caseInsensitive bool) string {
+	m, _ := values.(map[string]interface{})
+	if value, ok := m[key].(string); ok {
+		return value
+	}
+	if !caseInsensitive {
+		return ""
+	}
+	for k, v := range m {
+		if value, ok := v.(string); ok && strings.EqualFold(k, key) {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,110 @@
+package authorizer
+
+import "github.com/matthisstenius/lambda-router/v4/domain"
+
+// Policy statement effects
+const (
+	EffectAllow = "Allow"
+	EffectDeny  = "Deny"
+)
+
+// Policy response builder producing an IAM policy document for REST and HTTP API authorizers
+type Policy struct {
+	principalID        string
+	statements         []statement
+	context            map[string]interface{}
+	usageIdentifierKey string
+}
+
+type statement struct {
+	effect    string
+	resources []string
+}
+
+// NewPolicy initializer
+func NewPolicy(principalID string) *Policy {
+	return &Policy{principalID: principalID, context: map[string]interface{}{}}
+}
+
+// Allow invoking method ARNs, wildcards allowed
+func (p *Policy) Allow(resources ...string) *Policy {
+	p.statements = append(p.statements, statement{effect: EffectAllow, resources: resources})
+	return p
+}
+
+// Deny invoking method ARNs, wildcards allowed
+func (p *Policy) Deny(resources ...string) *Policy {
+	p.statements = append(p.statements, statement{effect: EffectDeny, resources: resources})
+	return p
+}
+
+// WithContext value passed on to the integration, only strings, numbers and booleans are supported
+func (p *Policy) WithContext(key string, value interface{}) *Policy {
+	p.context[key] = value
+	return p
+}
+
+// WithUsageIdentifierKey API key for usage plans
+func (p *Policy) WithUsageIdentifierKey(key string) *Policy {
+	p.usageIdentifierKey = key
+	return p
+}
+
+// Payload formatted authorizer response
+func (p *Policy) Payload() interface{} {
+	statements := make([]interface{}, 0, len(p.statements))
+	for _, s := range p.statements {
+		statements = append(statements, map[string]interface{}{
+			"Action":   "execute-api:Invoke",
+			"Effect":   s.effect,
+			"Resource": s.resources,
+		})
+	}
+
+	payload := map[string]interface{}{
+		"principalId": p.principalID,
+		"policyDocument": map[string]interface{}{
+			"Version":   "2012-10-17",
+			"Statement": statements,
+		},
+	}
+	if len(p.context) > 0 {
+		payload["context"] = p.context
+	}
+	if p.usageIdentifierKey != "" {
+		payload["usageIdentifierKey"] = p.usageIdentifierKey
+	}
+	return payload
+}
+
+// SimpleResponse for HTTP API authorizers with simple responses enabled
+type SimpleResponse struct {
+	isAuthorized bool
+	context      map[string]interface{}
+}
+
+// NewSimpleResponse initializer
+func NewSimpleResponse(isAuthorized bool, context map[string]interface{}) *SimpleResponse {
+	return &SimpleResponse{isAuthorized: isAuthorized, context: context}
+}
+
+// Payload formatted simple response
+func (r *SimpleResponse) Payload() interface{} {
+	payload := map[string]interface{}{"isAuthorized": r.isAuthorized}
+	if len(r.context) > 0 {
+		payload["context"] = r.context
+	}
+	return payload
+}
+
+type unauthorized struct{}
+
+// Payload of unauthorized response, never sent since router returns ErrUnauthorized
+func (u *unauthorized) Payload() interface{} {
+	return nil
+}
+
+// Unauthorized response making API Gateway respond 401
+func Unauthorized() domain.Response {
+	return &unauthorized{}
+}
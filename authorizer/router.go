@@ -0,0 +1,156 @@
+package authorizer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/logging"
+)
+
+// Authorizer event types
+const (
+	TypeToken   = "TOKEN"
+	TypeRequest = "REQUEST"
+)
+
+// ErrUnauthorized returned to API Gateway to respond 401
+var ErrUnauthorized = errors.New("Unauthorized")
+
+// Route mapping for handler
+type Route struct {
+	Handler func(i *Input) domain.Response
+}
+
+// Routes mappings for authorizer handlers keyed by TypeToken or TypeRequest
+type Routes map[string]Route
+
+// Router for Lambda authorizer events
+type Router struct {
+	routes       Routes
+	logging      domain.Logging
+	logger       domain.Logger
+	customLogger bool
+}
+
+// Option for configuring Router
+type Option func(r *Router)
+
+// WithLogging levels for incoming events and outgoing responses
+func WithLogging(logging domain.Logging) Option {
+	return func(r *Router) {
+		r.logging = logging
+	}
+}
+
+// WithLogger for routing and handler logging, defaults to logging.Default()
+func WithLogger(logger domain.Logger) Option {
+	return func(r *Router) {
+		r.logger = logger
+		r.customLogger = true
+	}
+}
+
+// DefaultLogger replaces logging.Default() unless a logger was set with WithLogger, used
+// by router.Event for Config.Logger
+func (r *Router) DefaultLogger(logger domain.Logger) {
+	if !r.customLogger {
+		r.logger = logger
+	}
+}
+
+// NewRouter initializer
+func NewRouter(routes Routes, opts ...Option) *Router {
+	r := &Router{routes: routes, logger: logging.Default()}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Route incoming event to corresponding handler
+func (r *Router) Route(evt map[string]interface{}) (domain.Response, error) {
+	return r.RouteContext(context.Background(), evt)
+}
+
+// RouteContext routes incoming event to corresponding handler with invocation context.
+// Handlers responding Unauthorized() make the router return ErrUnauthorized
+func (r *Router) RouteContext(ctx context.Context, evt map[string]interface{}) (domain.Response, error) {
+	route, ok := r.routes[r.RouteKey(evt)]
+	if !ok {
+		return nil, errors.New("handler func missing")
+	}
+	i := NewInput(evt)
+	i.ctx = ctx
+	i.logger = r.logger.With(domain.Fields{domain.CorrelationIDField: i.CorrelationID()})
+
+	res := route.Handler(i)
+	if _, ok := res.(*unauthorized); ok {
+		i.logger.Info("Router::Route() authorizer denied token", nil)
+		return nil, ErrUnauthorized
+	}
+	return res, nil
+}
+
+// IsMatch for TOKEN and REQUEST authorizer events
+func (r *Router) IsMatch(e map[string]interface{}) bool {
+	if e["type"] != TypeToken && e["type"] != TypeRequest {
+		return false
+	}
+	_, method := e["methodArn"]
+	_, route := e["routeArn"]
+	return method || route
+}
+
+// Logging levels for authorizer events
+func (r *Router) Logging() domain.Logging {
+	return r.logging
+}
+
+// RouteKey authorizer type
+func (r *Router) RouteKey(evt map[string]interface{}) string {
+	t, _ := evt["type"].(string)
+	return t
+}
+
+// CorrelationID for authorizer event
+func (r *Router) CorrelationID(evt map[string]interface{}) string {
+	return NewInput(evt).CorrelationID()
+}
+
+// Routes registered in router sorted by type
+func (r *Router) Routes() []domain.RouteInfo {
+	routes := make([]domain.RouteInfo, 0, len(r.routes))
+	for _, key := range r.keys() {
+		routes = append(routes, domain.RouteInfo{Source: domain.SourceAuthorizer, Key: key})
+	}
+	return routes
+}
+
+// Validate route table, reporting empty keys and missing handlers
+func (r *Router) Validate() error {
+	var errs []error
+	for _, key := range r.keys() {
+		if key == "" {
+			errs = append(errs, errors.New("route with empty type"))
+		}
+		if key != "" && key != TypeToken && key != TypeRequest {
+			errs = append(errs, fmt.Errorf("route %s: type must be %s or %s", key, TypeToken, TypeRequest))
+		}
+		if r.routes[key].Handler == nil {
+			errs = append(errs, fmt.Errorf("route %s: handler missing", key))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Router) keys() []string {
+	keys := make([]string, 0, len(r.routes))
+	for key := range r.routes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
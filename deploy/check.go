@@ -0,0 +1,214 @@
+package deploy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"gopkg.in/yaml.v2"
+)
+
+// Diff between routes and the events declared in a template
+type Diff struct {
+	// Missing routes without event in the template, e.g. sns arn:aws:sns:eu-west-1:123:orders
+	Missing []string
+	// Extra events in the template without route
+	Extra []string
+}
+
+// Empty reports whether routes and template match
+func (d *Diff) Empty() bool {
+	return len(d.Missing) == 0 && len(d.Extra) == 0
+}
+
+// Error describing every mismatch, nil when routes and template match
+func (d *Diff) Error() error {
+	var errs []error
+	for _, route := range d.Missing {
+		errs = append(errs, fmt.Errorf("route %s missing from template", route))
+	}
+	for _, event := range d.Extra {
+		errs = append(errs, fmt.Errorf("template event %s has no route", event))
+	}
+	return errors.Join(errs...)
+}
+
+// Check routes against events of a SAM template or Serverless config. Intrinsic functions
+// are compared by their argument, e.g. !Ref Topic as Topic
+func Check(routes []domain.RouteInfo, config Config, template []byte) (*Diff, error) {
+	var doc map[interface{}]interface{}
+	if err := yaml.Unmarshal(template, &doc); err != nil {
+		return nil, fmt.Errorf("could not parse template: %w", err)
+	}
+
+	declared := map[string]bool{}
+	switch {
+	case doc["Resources"] != nil:
+		for id, resource := range mapValue(doc["Resources"]) {
+			resource := mapValue(resource)
+			if resource["Type"] != "AWS::Serverless::Function" || !checked(config, id) {
+				continue
+			}
+			for _, event := range mapValue(mapValue(resource["Properties"])["Events"]) {
+				event := mapValue(event)
+				declared[samKey(stringValue(event["Type"]), mapValue(event["Properties"]))] = true
+			}
+		}
+	case doc["functions"] != nil:
+		for name, function := range mapValue(doc["functions"]) {
+			if !checked(config, name) {
+				continue
+			}
+			events, _ := mapValue(function)["events"].([]interface{})
+			for _, event := range events {
+				for eventType, properties := range mapValue(event) {
+					declared[serverlessKey(stringValue(eventType), properties)] = true
+				}
+			}
+		}
+	default:
+		return nil, errors.New("template has neither Resources nor functions")
+	}
+
+	registered := map[string]bool{}
+	for _, route := range routes {
+		if key := routeKey(route); key != "" {
+			registered[key] = true
+		}
+	}
+
+	diff := &Diff{}
+	for _, key := range sortedKeys(registered) {
+		if !declared[key] {
+			diff.Missing = append(diff.Missing, key)
+		}
+	}
+	for _, key := range sortedKeys(declared) {
+		if !registered[key] {
+			diff.Extra = append(diff.Extra, key)
+		}
+	}
+	return diff, nil
+}
+
+// CheckFile routes against SAM template or Serverless config at path, see Check
+func CheckFile(routes []domain.RouteInfo, config Config, path string) (*Diff, error) {
+	template, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Check(routes, config, template)
+}
+
+func checked(config Config, function interface{}) bool {
+	return config.Function == "" || config.Function == stringValue(function)
+}
+
+// Key identifying route in a template, empty for routes without event
+func routeKey(route domain.RouteInfo) string {
+	switch route.Source {
+	case domain.SourceHTTP:
+		return httpKey(route.Method, route.Key)
+	case domain.SourceSNS, domain.SourceDynamoDB:
+		return route.Source + " " + route.Key
+	case domain.SourceS3:
+		return s3Key(s3Prefix(route.Key))
+	case domain.SourceSchedule:
+		return domain.SourceSchedule + " " + ruleName(route.Key)
+	}
+	return ""
+}
+
+func samKey(eventType string, properties map[interface{}]interface{}) string {
+	switch eventType {
+	case "Api", "HttpApi":
+		return httpKey(stringValue(properties["Method"]), stringValue(properties["Path"]))
+	case "SNS":
+		return domain.SourceSNS + " " + stringValue(properties["Topic"])
+	case "DynamoDB":
+		return domain.SourceDynamoDB + " " + stringValue(properties["Stream"])
+	case "S3":
+		rules, _ := mapValue(mapValue(properties["Filter"])["S3Key"])["Rules"].([]interface{})
+		for _, rule := range rules {
+			if rule := mapValue(rule); strings.EqualFold(stringValue(rule["Name"]), "prefix") {
+				return s3Key(stringValue(rule["Value"]))
+			}
+		}
+		return s3Key("")
+	case "Schedule", "ScheduleV2":
+		return domain.SourceSchedule + " " + stringValue(properties["Name"])
+	}
+	return strings.ToLower(eventType)
+}
+
+func serverlessKey(eventType string, properties interface{}) string {
+	switch eventType {
+	case "http", "httpApi":
+		if s, ok := properties.(string); ok {
+			// Shorthand, e.g. GET /users/{id}
+			parts := strings.Fields(s)
+			if len(parts) == 2 {
+				return httpKey(parts[0], parts[1])
+			}
+		}
+		return httpKey(stringValue(mapValue(properties)["method"]), stringValue(mapValue(properties)["path"]))
+	case "sns":
+		if s, ok := properties.(string); ok {
+			return domain.SourceSNS + " " + s
+		}
+		return domain.SourceSNS + " " + stringValue(mapValue(properties)["arn"])
+	case "stream":
+		if s, ok := properties.(string); ok {
+			return domain.SourceDynamoDB + " " + s
+		}
+		return domain.SourceDynamoDB + " " + stringValue(mapValue(properties)["arn"])
+	case "s3":
+		rules, _ := mapValue(properties)["rules"].([]interface{})
+		for _, rule := range rules {
+			if prefix, ok := mapValue(rule)["prefix"]; ok {
+				return s3Key(stringValue(prefix))
+			}
+		}
+		return s3Key("")
+	case "schedule":
+		return domain.SourceSchedule + " " + stringValue(mapValue(properties)["name"])
+	}
+	return eventType
+}
+
+func httpKey(method string, path string) string {
+	return fmt.Sprintf("%s %s %s", domain.SourceHTTP, strings.ToUpper(method), "/"+strings.TrimPrefix(path, "/"))
+}
+
+func s3Key(prefix string) string {
+	return domain.SourceS3 + " /" + strings.Trim(prefix, "/")
+}
+
+func mapValue(v interface{}) map[interface{}]interface{} {
+	m, _ := v.(map[interface{}]interface{})
+	return m
+}
+
+// String value, intrinsic functions such as {"Fn::GetAtt": [Topic, Arn]} are
+// formatted by their argument
+func stringValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case map[interface{}]interface{}:
+		for _, arg := range val {
+			return stringValue(arg)
+		}
+	case []interface{}:
+		parts := make([]string, len(val))
+		for n, part := range val {
+			parts[n] = stringValue(part)
+		}
+		return strings.Join(parts, ".")
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,242 @@
+package deploy
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"gopkg.in/yaml.v2"
+)
+
+// StartingPosition default for DynamoDB stream events
+const StartingPosition = "TRIM_HORIZON"
+
+// ObjectCreated S3 event types routed to S3 handlers
+const ObjectCreated = "s3:ObjectCreated:*"
+
+var nonAlphanumeric = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// Config for event generation
+type Config struct {
+	// HTTPAPI generates HttpApi events instead of REST Api events for HTTP routes
+	HTTPAPI bool
+	// StartingPosition of DynamoDB stream events, defaults to TRIM_HORIZON
+	StartingPosition string
+	// Bucket of S3 events, the bucket's logical id in SAM templates and its name in
+	// Serverless configs
+	Bucket string
+	// Schedules expression per schedule route key, e.g. rate(1 day)
+	Schedules map[string]string
+	// Function checked by Check, SAM logical id or Serverless function name. Events of
+	// every function are checked when empty
+	Function string
+}
+
+// SAMEvent entry of a SAM function's Events property
+type SAMEvent struct {
+	Type       string                 `json:"Type" yaml:"Type"`
+	Properties map[string]interface{} `json:"Properties" yaml:"Properties"`
+}
+
+// SAMEvents keyed by logical id
+type SAMEvents map[string]*SAMEvent
+
+// ServerlessEvents entries of a Serverless Framework function's events list
+type ServerlessEvents []map[string]interface{}
+
+// YAML encoded events
+func (e SAMEvents) YAML() ([]byte, error) {
+	return yaml.Marshal(map[string]interface{}{"Events": map[string]*SAMEvent(e)})
+}
+
+// YAML encoded events
+func (e ServerlessEvents) YAML() ([]byte, error) {
+	return yaml.Marshal(map[string]interface{}{"events": []map[string]interface{}(e)})
+}
+
+// SAM events for routes as listed by router.Event.Routes(). Authorizer routes are left
+// out as authorizers are configured on the API. Schedule routes require an expression in
+// Config.Schedules and S3 routes a Config.Bucket
+func SAM(routes []domain.RouteInfo, config Config) (SAMEvents, error) {
+	events := SAMEvents{}
+	var errs []error
+	for _, route := range routes {
+		event, err := samEvent(route, config)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if event == nil {
+			continue
+		}
+		id := logicalID(route)
+		for n := 2; events[id] != nil; n++ {
+			id = fmt.Sprintf("%s%d", logicalID(route), n)
+		}
+		events[id] = event
+	}
+	return events, errors.Join(errs...)
+}
+
+func samEvent(route domain.RouteInfo, config Config) (*SAMEvent, error) {
+	switch route.Source {
+	case domain.SourceHTTP:
+		if config.HTTPAPI {
+			return &SAMEvent{Type: "HttpApi", Properties: map[string]interface{}{"Path": route.Key, "Method": route.Method}}, nil
+		}
+		return &SAMEvent{Type: "Api", Properties: map[string]interface{}{"Path": route.Key, "Method": strings.ToLower(route.Method)}}, nil
+	case domain.SourceSNS:
+		return &SAMEvent{Type: "SNS", Properties: map[string]interface{}{"Topic": route.Key}}, nil
+	case domain.SourceDynamoDB:
+		return &SAMEvent{Type: "DynamoDB", Properties: map[string]interface{}{
+			"Stream":           route.Key,
+			"StartingPosition": startingPosition(config),
+		}}, nil
+	case domain.SourceS3:
+		if config.Bucket == "" {
+			return nil, fmt.Errorf("%s %s: bucket missing", route.Source, route.Key)
+		}
+		properties := map[string]interface{}{
+			"Bucket": map[string]string{"Ref": config.Bucket},
+			"Events": ObjectCreated,
+		}
+		if prefix := s3Prefix(route.Key); prefix != "" {
+			properties["Filter"] = map[string]interface{}{
+				"S3Key": map[string]interface{}{
+					"Rules": []map[string]string{{"Name": "prefix", "Value": prefix}},
+				},
+			}
+		}
+		return &SAMEvent{Type: "S3", Properties: properties}, nil
+	case domain.SourceSchedule:
+		expression, ok := config.Schedules[route.Key]
+		if !ok {
+			return nil, fmt.Errorf("%s %s: schedule expression missing", route.Source, route.Key)
+		}
+		return &SAMEvent{Type: "Schedule", Properties: map[string]interface{}{
+			"Name":     ruleName(route.Key),
+			"Schedule": expression,
+		}}, nil
+	}
+	return nil, nil
+}
+
+// Serverless Framework events for routes as listed by router.Event.Routes(), see SAM
+func Serverless(routes []domain.RouteInfo, config Config) (ServerlessEvents, error) {
+	events := ServerlessEvents{}
+	var errs []error
+	for _, route := range routes {
+		event, err := serverlessEvent(route, config)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if event != nil {
+			events = append(events, event)
+		}
+	}
+	return events, errors.Join(errs...)
+}
+
+func serverlessEvent(route domain.RouteInfo, config Config) (map[string]interface{}, error) {
+	switch route.Source {
+	case domain.SourceHTTP:
+		if config.HTTPAPI {
+			return map[string]interface{}{"httpApi": map[string]string{"path": route.Key, "method": route.Method}}, nil
+		}
+		return map[string]interface{}{"http": map[string]string{"path": route.Key, "method": strings.ToLower(route.Method)}}, nil
+	case domain.SourceSNS:
+		return map[string]interface{}{"sns": map[string]string{"arn": route.Key}}, nil
+	case domain.SourceDynamoDB:
+		return map[string]interface{}{"stream": map[string]string{
+			"type":             "dynamodb",
+			"arn":              route.Key,
+			"startingPosition": startingPosition(config),
+		}}, nil
+	case domain.SourceS3:
+		if config.Bucket == "" {
+			return nil, fmt.Errorf("%s %s: bucket missing", route.Source, route.Key)
+		}
+		s3 := map[string]interface{}{"bucket": config.Bucket, "event": ObjectCreated}
+		if prefix := s3Prefix(route.Key); prefix != "" {
+			s3["rules"] = []map[string]string{{"prefix": prefix}}
+		}
+		return map[string]interface{}{"s3": s3}, nil
+	case domain.SourceSchedule:
+		expression, ok := config.Schedules[route.Key]
+		if !ok {
+			return nil, fmt.Errorf("%s %s: schedule expression missing", route.Source, route.Key)
+		}
+		return map[string]interface{}{"schedule": map[string]interface{}{
+			"name": ruleName(route.Key),
+			"rate": []string{expression},
+		}}, nil
+	}
+	return nil, nil
+}
+
+func startingPosition(config Config) string {
+	if config.StartingPosition != "" {
+		return config.StartingPosition
+	}
+	return StartingPosition
+}
+
+// S3 key prefix of objects in route folder, e.g. uploads/ for /uploads
+func s3Prefix(key string) string {
+	folder := strings.Trim(key, "/")
+	if folder == "" {
+		return ""
+	}
+	return folder + "/"
+}
+
+// Rule name of schedule route key, e.g. nightly for arn:aws:events:eu-west-1:123:rule/nightly
+func ruleName(key string) string {
+	return key[strings.LastIndexAny(key, ":/")+1:]
+}
+
+// Logical id of route's event, e.g. GetUsersId or SNSOrdersCreated
+func logicalID(route domain.RouteInfo) string {
+	var name string
+	switch route.Source {
+	case domain.SourceHTTP:
+		name = strings.ToLower(route.Method) + " " + route.Key
+	case domain.SourceDynamoDB:
+		name = "DynamoDB " + tableName(route.Key)
+	case domain.SourceS3:
+		name = "S3 " + route.Key
+	case domain.SourceSNS:
+		name = "SNS " + ruleName(route.Key)
+	default:
+		name = route.Source + " " + ruleName(route.Key)
+	}
+
+	id := ""
+	for _, word := range nonAlphanumeric.Split(name, -1) {
+		if word != "" {
+			id += strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return id
+}
+
+// Table name of stream ARN, e.g. Orders for arn:aws:dynamodb:eu-west-1:123:table/Orders/stream/...
+func tableName(arn string) string {
+	if idx := strings.Index(arn, ":table/"); idx >= 0 {
+		return strings.Split(arn[idx+len(":table/"):], "/")[0]
+	}
+	return arn
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
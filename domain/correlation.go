@@ -0,0 +1,12 @@
+package domain
+
+// CorrelationIDHeader carrying correlation id in HTTP headers and message attributes
+const CorrelationIDHeader = "X-Correlation-Id"
+
+// CorrelationIDField for correlation id in log lines
+const CorrelationIDField = "correlationId"
+
+// Correlator is implemented by routers able to extract a correlation id from an event
+type Correlator interface {
+	CorrelationID(evt map[string]interface{}) string
+}
@@ -1,5 +1,23 @@
 package domain
 
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Event sources matched by router.Event
+const (
+	SourceHTTP       = "http"
+	SourceSchedule   = "schedule"
+	SourceDynamoDB   = "dynamodb"
+	SourceS3         = "s3"
+	SourceSNS        = "sns"
+	SourceAuthorizer = "authorizer"
+)
+
 // Response ...
 type Response interface {
 	Payload() interface{}
@@ -11,10 +29,59 @@ type Router interface {
 	IsMatch(evt map[string]interface{}) bool
 }
 
+// ContextRouter is implemented by routers accepting the invocation context
+type ContextRouter interface {
+	RouteContext(ctx context.Context, evt map[string]interface{}) (Response, error)
+}
+
+// RouteKeyer is implemented by routers able to name the route an event matches
+type RouteKeyer interface {
+	RouteKey(evt map[string]interface{}) string
+}
+
+// MatchMode for comparing claim values against roles
+type MatchMode int
+
+const (
+	// MatchAny grants access when claims contain any of the roles, default
+	MatchAny MatchMode = iota
+	// MatchAll grants access when claims contain all of the roles
+	MatchAll
+)
+
 // Access DTO for roles and provider
 type Access struct {
 	Roles []string
 	Key   string
+	Match MatchMode
+}
+
+// Allows access for claims holding roles under Key. String claims match a role by their
+// whole value before being split into multiple values
+func (a *Access) Allows(claims *AuthClaims) bool {
+	values := map[string]bool{}
+	if whole, ok := claims.Get(a.Key).(string); ok {
+		values[strings.TrimSpace(whole)] = true
+	}
+	for _, v := range claims.GetStrings(a.Key) {
+		values[v] = true
+	}
+
+	matches := 0
+	for _, r := range a.Roles {
+		if values[r] {
+			matches++
+		}
+	}
+	if a.Match == MatchAll {
+		return len(a.Roles) > 0 && matches == len(a.Roles)
+	}
+	return matches > 0
+}
+
+// TokenVerifier verifies bearer tokens and returns their claims
+type TokenVerifier interface {
+	Verify(token string) (map[string]interface{}, error)
 }
 
 // AuthClaims for current authenticated user
@@ -34,3 +101,48 @@ func (ac *AuthClaims) Get(key string) interface{} {
 	}
 	return ac.claims[key]
 }
+
+// GetStrings multi-valued claim by key. Handles arrays, JSON encoded arrays and
+// strings delimited by comma or space such as cognito:groups in REST API claims
+func (ac *AuthClaims) GetStrings(key string) []string {
+	switch v := ac.Get(key).(type) {
+	case []string:
+		return v
+	case []interface{}:
+		out := make([]string, 0, len(v))
+		for _, value := range v {
+			out = append(out, fmt.Sprint(value))
+		}
+		return out
+	case string:
+		return splitClaim(v)
+	case nil:
+		return nil
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
+
+func splitClaim(value string) []string {
+	value = strings.TrimSpace(value)
+	var decoded []string
+	if strings.HasPrefix(value, "[") && json.Unmarshal([]byte(value), &decoded) == nil {
+		return decoded
+	}
+
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+}
+
+// FieldError describes a single invalid request field
+type FieldError struct {
+	// Field name as sent by the client, e.g. the query param or JSON property
+	Field string `json:"field"`
+	// Source of the field: path, query, header or body
+	Source string `json:"source"`
+	// Path to the field within the source, e.g. items[0].name
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
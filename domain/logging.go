@@ -0,0 +1,42 @@
+package domain
+
+// LogLevel for event and response logging
+type LogLevel int
+
+const (
+	// LogInfo logs on info level, default
+	LogInfo LogLevel = iota
+	// LogDebug logs on debug level
+	LogDebug
+	// LogOff disables logging
+	LogOff
+)
+
+// Logging levels for incoming events and outgoing responses
+type Logging struct {
+	Event    LogLevel
+	Response LogLevel
+}
+
+// LoggingRouter is implemented by routers with their own logging levels
+type LoggingRouter interface {
+	Logging() Logging
+}
+
+// Fields custom data to be logged
+type Fields map[string]interface{}
+
+// Logger used by routers, inputs and the event handler
+type Logger interface {
+	Debug(message string, fields Fields)
+	Info(message string, fields Fields)
+	Error(message string, fields Fields)
+	// With returns a Logger attaching fields to every log line
+	With(fields Fields) Logger
+}
+
+// LoggerDefaulter is implemented by routers accepting a default logger, routers
+// configured with their own logger keep it
+type LoggerDefaulter interface {
+	DefaultLogger(logger Logger)
+}
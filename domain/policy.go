@@ -0,0 +1,74 @@
+package domain
+
+// PolicyRequest data policies are evaluated against
+type PolicyRequest struct {
+	Claims     *AuthClaims
+	PathParams map[string]string
+}
+
+// Policy deciding if an authenticated request is authorized
+type Policy interface {
+	Authorize(req *PolicyRequest) bool
+}
+
+// PolicyFunc custom predicate as Policy
+type PolicyFunc func(req *PolicyRequest) bool
+
+// Authorize by calling predicate
+func (f PolicyFunc) Authorize(req *PolicyRequest) bool {
+	return f(req)
+}
+
+// Authorize by matching roles in claims
+func (a *Access) Authorize(req *PolicyRequest) bool {
+	return a.Allows(req.Claims)
+}
+
+// All authorizes when every policy authorizes
+func All(policies ...Policy) Policy {
+	return PolicyFunc(func(req *PolicyRequest) bool {
+		for _, p := range policies {
+			if !p.Authorize(req) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Any authorizes when at least one policy authorizes
+func Any(policies ...Policy) Policy {
+	return PolicyFunc(func(req *PolicyRequest) bool {
+		for _, p := range policies {
+			if p.Authorize(req) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Not negates policy
+func Not(policy Policy) Policy {
+	return PolicyFunc(func(req *PolicyRequest) bool {
+		return !policy.Authorize(req)
+	})
+}
+
+// Scopes authorizes when the OAuth scope claim holds every given scope
+func Scopes(scopes ...string) Policy {
+	return &Access{Roles: scopes, Key: "scope", Match: MatchAll}
+}
+
+// ClaimMatchesParam authorizes when claim equals path param, e.g. custom:tenantId == {tenantId}
+func ClaimMatchesParam(claim string, param string) Policy {
+	return PolicyFunc(func(req *PolicyRequest) bool {
+		value, ok := req.Claims.Get(claim).(string)
+		return ok && value != "" && value == req.PathParams[param]
+	})
+}
+
+// Owner authorizes when the subject claim equals path param, e.g. sub == {userId}
+func Owner(param string) Policy {
+	return ClaimMatchesParam("sub", param)
+}
@@ -0,0 +1,29 @@
+package domain
+
+// RouteInfo describes a registered route
+type RouteInfo struct {
+	// Source of events routed, e.g. SourceHTTP
+	Source string
+	// Key the route is registered on: resource template, stream ARN, S3 prefix, topic
+	// ARN, schedule rule or authorizer type
+	Key string
+	// Method for HTTP routes
+	Method string
+	Access *Access
+	Policy Policy
+	// Payload value documenting the decoded request, message or item type, nil when
+	// the route's handler is untyped
+	Payload interface{}
+	// Response value documenting the success response of HTTP routes
+	Response interface{}
+}
+
+// RouteLister is implemented by routers able to list their routes
+type RouteLister interface {
+	Routes() []RouteInfo
+}
+
+// Validator is implemented by routers able to validate their route table
+type Validator interface {
+	Validate() error
+}
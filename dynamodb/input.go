@@ -1,22 +1,36 @@
 package dynamodb
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"strconv"
 
-	"github.com/matthisstenius/logger"
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/logging"
 )
 
 // Input for parsed DynamoDB event
 // TODO: Write tests
 type Input struct {
-	event map[string]interface{}
+	event  map[string]interface{}
+	logger domain.Logger
+	ctx    context.Context
 }
 
 // NewInput initializer
 func NewInput(e map[string]interface{}) *Input {
-	return &Input{event: e}
+	return &Input{event: e, logger: logging.Default(), ctx: context.Background()}
+}
+
+// Context for current invocation
+func (i *Input) Context() context.Context {
+	return i.ctx
+}
+
+// Logger for current event
+func (i *Input) Logger() domain.Logger {
+	return i.logger
 }
 
 // ParseOldImage from DynamoDB event
@@ -24,9 +38,9 @@ func (i *Input) ParseOldImage(out interface{}) error {
 	record := i.event["Records"].([]interface{})[0]
 	image, ok := record.(map[string]interface{})["dynamodb"].(map[string]interface{})["OldImage"].(map[string]interface{})
 	if !ok {
-		logger.WithFields(logger.Fields{
+		i.logger.Error("StreamInput::ParseOldImage() missing OldImage attribute in event", domain.Fields{
 			"record": record,
-		}).Error("StreamInput::ParseOldImage() missing OldImage attribute in event")
+		})
 		return errors.New("missing OldImage attribute in event")
 	}
 
@@ -41,9 +55,9 @@ func (i *Input) ParseNewImage(out interface{}) error {
 	record := i.event["Records"].([]interface{})[0]
 	image, ok := record.(map[string]interface{})["dynamodb"].(map[string]interface{})["NewImage"].(map[string]interface{})
 	if !ok {
-		logger.WithFields(logger.Fields{
+		i.logger.Error("StreamInput::ParseNewImage() missing NewImage attribute in event", domain.Fields{
 			"record": record,
-		}).Error("StreamInput::ParseNewImage() missing NewImage attribute in event")
+		})
 		return errors.New("missing NewImage attribute in event")
 	}
 
@@ -53,20 +67,27 @@ func (i *Input) ParseNewImage(out interface{}) error {
 	return nil
 }
 
+func (i *Input) hasImage(name string) bool {
+	record, _ := i.event["Records"].([]interface{})[0].(map[string]interface{})
+	stream, _ := record["dynamodb"].(map[string]interface{})
+	_, ok := stream[name].(map[string]interface{})
+	return ok
+}
+
 func (i *Input) unmarshalAttributes(attributes map[string]interface{}, out interface{}) error {
 	encoded, err := json.Marshal(i.recursivelyFlattenStreamAttributes(attributes))
 	if err != nil {
-		logger.WithFields(logger.Fields{
+		i.logger.Error("StreamInput::unmarshalAttributes() could not marshal json", domain.Fields{
 			"error": err,
-		}).Error("StreamInput::unmarshalAttributes() could not marshal json")
+		})
 		return errors.New("could not marshal json")
 	}
 
 	if err := json.Unmarshal(encoded, out); err != nil {
-		logger.WithFields(logger.Fields{
+		i.logger.Error("StreamInput::unmarshalAttributes() could not unmarshal json", domain.Fields{
 			"error":   err,
 			"encoded": string(encoded),
-		}).Error("StreamInput::unmarshalAttributes() could not unmarshal json")
+		})
 		return errors.New("could not unmarshal json")
 	}
 	return nil
@@ -116,3 +137,14 @@ func (i *Input) EventType() EventType {
 	record := i.event["Records"].([]interface{})[0]
 	return EventType(record.(map[string]interface{})["eventName"].(string))
 }
+
+// CorrelationID from stream record event id
+func (i *Input) CorrelationID() string {
+	records, ok := i.event["Records"].([]interface{})
+	if !ok || len(records) == 0 {
+		return ""
+	}
+	record, _ := records[0].(map[string]interface{})
+	id, _ := record["eventID"].(string)
+	return id
+}
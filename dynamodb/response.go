@@ -1,9 +1,10 @@
 package dynamodb
 
-import "github.com/matthisstenius/logger"
-
 // Response for dynamodb event
-type Response struct{}
+type Response struct {
+	message string
+	err     error
+}
 
 // Payload data
 func (r *Response) Payload() interface{} {
@@ -12,6 +13,10 @@ func (r *Response) Payload() interface{} {
 
 // NewResponse initializer
 func NewResponse(message string) *Response {
-	logger.WithFields(logger.Fields{"message": message}).Info("Response::NewResponse() DynamoDB handler responded")
-	return &Response{}
+	return &Response{message: message}
+}
+
+// NewErrorResponse failing the invocation with err so that Lambda retries the event
+func NewErrorResponse(err error) *Response {
+	return &Response{message: err.Error(), err: err}
 }
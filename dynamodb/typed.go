@@ -0,0 +1,43 @@
+package dynamodb
+
+import (
+	"context"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+)
+
+// Change to an item in a stream record, Old is nil for inserts and New for removals
+type Change[Item any] struct {
+	Type EventType
+	Old  *Item
+	New  *Item
+}
+
+// Typed Route decoding the old and new images once before calling fn. Images that can
+// not be decoded are logged and acknowledged as retrying would not help, errors returned
+// by fn fail the invocation so that Lambda retries the batch
+func Typed[Item any](fn func(ctx context.Context, change Change[Item]) error) Route {
+	var item Item
+	return Route{
+		Item: item,
+		Handler: func(i *Input) domain.Response {
+			change := Change[Item]{Type: i.EventType()}
+			if i.hasImage("OldImage") {
+				change.Old = new(Item)
+				if err := i.ParseOldImage(change.Old); err != nil {
+					return NewResponse("invalid record discarded")
+				}
+			}
+			if i.hasImage("NewImage") {
+				change.New = new(Item)
+				if err := i.ParseNewImage(change.New); err != nil {
+					return NewResponse("invalid record discarded")
+				}
+			}
+			if err := fn(i.Context(), change); err != nil {
+				return NewErrorResponse(err)
+			}
+			return NewResponse("record handled")
+		},
+	}
+}
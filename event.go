@@ -1,11 +1,17 @@
 package router
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"runtime/debug"
+	"time"
 
 	"github.com/matthisstenius/lambda-router/v4/domain"
-	"github.com/matthisstenius/logger"
+	"github.com/matthisstenius/lambda-router/v4/logging"
+	"github.com/matthisstenius/lambda-router/v4/metrics"
+	"github.com/matthisstenius/lambda-router/v4/redact"
+	"github.com/matthisstenius/lambda-router/v4/tracing"
 )
 
 // Event ...
@@ -20,54 +26,232 @@ type Config struct {
 	DynamoDB  domain.Router
 	S3        domain.Router
 	SNS       domain.Router
+	// Authorizer is matched before HTTP since REQUEST authorizer events carry httpMethod
+	Authorizer domain.Router
+	// Redactor for logged events and responses, defaults to redact.Default()
+	Redactor *redact.Redactor
+	// Logger for events, responses and panics, defaults to logging.Default(). Also used by
+	// routers not configured with their own WithLogger
+	Logger domain.Logger
+	// Metrics emitted per invocation in CloudWatch Embedded Metric Format, disabled when nil
+	Metrics *MetricsConfig
+	// Tracer for spans around routing and handlers, disabled when nil
+	Tracer tracing.Tracer
 }
 
 // NewEvent initialization for Event
 func NewEvent(config *Config) *Event {
-	return &Event{config: config}
+	e := &Event{config: config}
+	if config.Logger != nil {
+		for _, s := range e.routers() {
+			if r, ok := s.router.(domain.LoggerDefaulter); ok {
+				r.DefaultLogger(config.Logger)
+			}
+		}
+	}
+	return e
 }
 
 // Handle event by routing matched event
 func (e *Event) Handle(event interface{}) (interface{}, error) {
+	return e.HandleContext(context.Background(), event)
+}
+
+// HandleContext routes matched event with invocation context passed on to handlers
+func (e *Event) HandleContext(ctx context.Context, event interface{}) (interface{}, error) {
 	evt := event.(map[string]interface{})
-	logger.WithFields(logger.Fields{
-		"event": event,
-	}).Info("Incoming event")
 	defer e.logPanic()
 
+	router, source := e.match(evt)
+	if router == nil {
+		e.log(e.logger(), domain.LogInfo, "event", evt, "Incoming event")
+		return nil, errors.New("unknown event")
+	}
+
+	log := e.logger()
+	if r, ok := router.(domain.Correlator); ok {
+		log = log.With(domain.Fields{domain.CorrelationIDField: r.CorrelationID(evt)})
+	}
+	var logging domain.Logging
+	if r, ok := router.(domain.LoggingRouter); ok {
+		logging = r.Logging()
+	}
+	e.log(log, logging.Event, "event", evt, "Incoming event")
+
+	key := routeKey(router, evt)
+	var m *metrics.Metrics
+	if e.config.Metrics != nil {
+		m = e.config.Metrics.newMetrics(source, key)
+		ctx = metrics.NewContext(ctx, m)
+	}
+
+	ctx = tracing.ContextWithCarrier(ctx, tracing.Extract(evt))
+	ctx, span := e.tracer().Start(ctx, "router.Event.Handle", tracing.Attributes{
+		tracing.AttributeSource: source,
+		tracing.AttributeRoute:  key,
+	})
+	defer span.End()
+
+	start := time.Now()
+	payload, err := e.route(ctx, router, evt, source, key)
+	if payload != nil {
+		e.log(log, logging.Response, "response", payload, "Outgoing response")
+	}
+	if status, ok := statusCode(payload); ok {
+		span.SetAttributes(tracing.Attributes{tracing.AttributeStatusCode: status})
+	}
+	if err != nil {
+		span.RecordError(err)
+	}
+	if m != nil {
+		e.config.Metrics.flush(log, m, time.Since(start), payload, err)
+	}
+	return payload, err
+}
+
+// Routes event to matched router within a span named after the route
+func (e *Event) route(ctx context.Context, router domain.Router, evt map[string]interface{}, source string, key string) (interface{}, error) {
+	name := key
+	if name == "" {
+		name = source
+	}
+	ctx, span := e.tracer().Start(ctx, name, tracing.Attributes{
+		tracing.AttributeSource: source,
+		tracing.AttributeRoute:  key,
+	})
+	defer span.End()
+
 	var response domain.Response
 	var err error
-	switch true {
-	case e.config.HTTP != nil && e.config.HTTP.IsMatch(evt):
-		response, err = e.config.HTTP.Route(evt)
-		break
-	case e.config.Scheduled != nil && e.config.Scheduled.IsMatch(evt):
-		response, err = e.config.Scheduled.Route(evt)
-		break
-	case e.config.DynamoDB != nil && e.config.DynamoDB.IsMatch(evt):
-		response, err = e.config.DynamoDB.Route(evt)
-		break
-	case e.config.S3 != nil && e.config.S3.IsMatch(evt):
-		response, err = e.config.S3.Route(evt)
-		break
-	case e.config.SNS != nil && e.config.SNS.IsMatch(evt):
-		response, err = e.config.SNS.Route(evt)
-		break
-	default:
-		response, err = nil, errors.New("unknown event")
-	}
-
-	if response != nil {
-		return response.Payload(), err
-	}
-	return nil, err
+	if r, ok := router.(domain.ContextRouter); ok {
+		response, err = r.RouteContext(ctx, evt)
+	} else {
+		response, err = router.Route(evt)
+	}
+	if err != nil {
+		span.RecordError(err)
+	}
+	if response == nil {
+		return nil, err
+	}
+
+	payload := response.Payload()
+	if status, ok := statusCode(payload); ok {
+		span.SetAttributes(tracing.Attributes{tracing.AttributeStatusCode: status})
+	}
+	return payload, err
+}
+
+// Routes registered in every configured router, in the order events are matched
+func (e *Event) Routes() []domain.RouteInfo {
+	var routes []domain.RouteInfo
+	for _, s := range e.routers() {
+		if r, ok := s.router.(domain.RouteLister); ok {
+			routes = append(routes, r.Routes()...)
+		}
+	}
+	return routes
+}
+
+// Validate route tables of every configured router, intended as a startup self-check
+func (e *Event) Validate() error {
+	var errs []error
+	for _, s := range e.routers() {
+		if r, ok := s.router.(domain.Validator); ok {
+			if err := r.Validate(); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", s.source, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type sourceRouter struct {
+	router domain.Router
+	source string
+}
+
+// Configured routers in the order events are matched
+func (e *Event) routers() []sourceRouter {
+	var routers []sourceRouter
+	for _, s := range []sourceRouter{
+		{e.config.Authorizer, domain.SourceAuthorizer},
+		{e.config.HTTP, domain.SourceHTTP},
+		{e.config.Scheduled, domain.SourceSchedule},
+		{e.config.DynamoDB, domain.SourceDynamoDB},
+		{e.config.S3, domain.SourceS3},
+		{e.config.SNS, domain.SourceSNS},
+	} {
+		if s.router != nil {
+			routers = append(routers, s)
+		}
+	}
+	return routers
+}
+
+func (e *Event) match(evt map[string]interface{}) (domain.Router, string) {
+	for _, s := range e.routers() {
+		if s.router.IsMatch(evt) {
+			return s.router, s.source
+		}
+	}
+	return nil, ""
+}
+
+// HTTP status code of response payload
+func statusCode(payload interface{}) (int, bool) {
+	res, ok := payload.(map[string]interface{})
+	if !ok {
+		return 0, false
+	}
+	status, ok := res["statusCode"].(int)
+	return status, ok
+}
+
+func routeKey(router domain.Router, evt map[string]interface{}) string {
+	if r, ok := router.(domain.RouteKeyer); ok {
+		return r.RouteKey(evt)
+	}
+	return ""
+}
+
+// Logs redacted value on given level
+func (e *Event) log(logger domain.Logger, level domain.LogLevel, key string, value interface{}, message string) {
+	if level == domain.LogOff {
+		return
+	}
+
+	redactor := e.config.Redactor
+	if redactor == nil {
+		redactor = redact.Default()
+	}
+	fields := domain.Fields{key: redactor.Payload(value)}
+	if level == domain.LogDebug {
+		logger.Debug(message, fields)
+		return
+	}
+	logger.Info(message, fields)
+}
+
+func (e *Event) tracer() tracing.Tracer {
+	if e.config.Tracer == nil {
+		return tracing.Nop()
+	}
+	return e.config.Tracer
+}
+
+func (e *Event) logger() domain.Logger {
+	if e.config.Logger == nil {
+		return logging.Default()
+	}
+	return e.config.Logger
 }
 
 func (e *Event) logPanic() {
 	if r := recover(); r != nil {
-		logger.WithFields(logger.Fields{
+		e.logger().Error("Unexpected panic", domain.Fields{
 			"error": r,
 			"stack": string(debug.Stack()),
-		}).Error("Unexpected panic")
+		})
 	}
 }
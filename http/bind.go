@@ -0,0 +1,375 @@
+package http
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+)
+
+// Binding sources matched by struct tags
+const (
+	SourcePath   = "path"
+	SourceQuery  = "query"
+	SourceHeader = "header"
+	SourceBody   = "body"
+	SourceForm   = "form"
+)
+
+// BindError with field level errors, responded as 400 by the router
+type BindError struct {
+	Message string              `json:"message"`
+	Fields  []domain.FieldError `json:"fields"`
+}
+
+func (e *BindError) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		parts = append(parts, fmt.Sprintf("%s %s: %s", f.Source, f.Field, f.Message))
+	}
+	return fmt.Sprintf("%s: %s", e.Message, strings.Join(parts, "; "))
+}
+
+var (
+	textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	timeType        = reflect.TypeOf(time.Time{})
+	fileType        = reflect.TypeOf(&File{})
+	filesType       = reflect.TypeOf([]*File{})
+)
+
+// Bind request into struct pointed to by out. A JSON body is decoded first, then
+// fields tagged path:"id", query:"limit", header:"X-Tenant" or form:"name" are set,
+// falling back to default:"10" when the value is missing. Supports strings, numbers,
+// bools, time.Time, time.Duration, slices, pointers and encoding.TextUnmarshaler, form
+// fields of type *File or []*File receive multipart uploads. A single value bound to a
+// slice is split on commas, e.g. ?tag=a,b, except for []byte which receives the raw
+// value. Bodies that are not forms are decoded as JSON whatever their content type, use
+// Route.Consumes to restrict media types. Fields tagged path, query or header are never
+// set from the body. Returns ErrBodyTooLarge when the body exceeds the router's max body
+// size and a plain error for tagged fields of unsupported types
+func (i *Input) Bind(out interface{}) error {
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("bind target must be a pointer to struct, got %T", out)
+	}
+
+	bindErr := &BindError{Message: "invalid request"}
+	// Param fields are only bound from their source, never from the body
+	params := paramFields(v.Elem(), nil)
+	saved := make([]reflect.Value, len(params))
+	for n, param := range params {
+		saved[n] = reflect.New(param.Type()).Elem()
+		saved[n].Set(param)
+	}
+	if err := i.bindBody(out, bindErr); err != nil {
+		return err
+	}
+	for n, param := range params {
+		param.Set(saved[n])
+	}
+	if err := i.bindFields(v.Elem(), bindErr); err != nil {
+		return err
+	}
+
+	if len(bindErr.Fields) > 0 {
+		return bindErr
+	}
+	return nil
+}
+
+// Parses form bodies for form tagged fields and decodes other bodies into out as JSON
+func (i *Input) bindBody(out interface{}, bindErr *BindError) error {
+	body, err := i.Body()
+	if err == ErrBodyTooLarge {
+		return err
+	}
+	if err != nil {
+		bindErr.Fields = append(bindErr.Fields, domain.FieldError{Source: SourceBody, Message: err.Error()})
+		return nil
+	}
+	if len(body) == 0 {
+		return nil
+	}
+
+	if mediaType := i.ContentType(); mediaType == MediaTypeForm || mediaType == MediaTypeMultipart {
+		if _, err := i.parseForm(); err != nil {
+			bindErr.Fields = append(bindErr.Fields, domain.FieldError{Source: SourceBody, Message: err.Error()})
+		}
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		bindErr.Fields = append(bindErr.Fields, bodyFieldError(err))
+	}
+	return nil
+}
+
+func bodyFieldError(err error) domain.FieldError {
+	if typeErr, ok := err.(*json.UnmarshalTypeError); ok {
+		return domain.FieldError{
+			Field:   typeErr.Field,
+			Source:  SourceBody,
+			Path:    typeErr.Field,
+			Message: fmt.Sprintf("must be %s", typeName(typeErr.Type)),
+		}
+	}
+	return domain.FieldError{Source: SourceBody, Message: "could not parse body as JSON"}
+}
+
+// Fields of v tagged path, query or header, including those of embedded structs
+func paramFields(v reflect.Value, fields []reflect.Value) []reflect.Value {
+	t := v.Type()
+	for n := 0; n < t.NumField(); n++ {
+		field := t.Field(n)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			fields = paramFields(v.Field(n), fields)
+			continue
+		}
+		if source, _ := bindingTag(field); field.PkgPath == "" && source != "" && source != SourceForm {
+			fields = append(fields, v.Field(n))
+		}
+	}
+	return fields
+}
+
+// Binds tagged fields, returning an error for field types that can not be bound
+func (i *Input) bindFields(v reflect.Value, bindErr *BindError) error {
+	t := v.Type()
+	for n := 0; n < t.NumField(); n++ {
+		field := t.Field(n)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := i.bindFields(v.Field(n), bindErr); err != nil {
+				return err
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+
+		source, name := bindingTag(field)
+		if source == "" {
+			continue
+		}
+		if source == SourceForm && (field.Type == fileType || field.Type == filesType) {
+			i.bindFiles(v.Field(n), name)
+			continue
+		}
+		if !bindable(field.Type) {
+			return fmt.Errorf("field %s: unsupported %s field type %s", field.Name, source, field.Type)
+		}
+		values := i.sourceValues(source, name)
+		if len(values) == 0 {
+			def, ok := field.Tag.Lookup("default")
+			if !ok {
+				continue
+			}
+			values = []string{def}
+		}
+
+		if err := setValue(v.Field(n), values); err != nil {
+			bindErr.Fields = append(bindErr.Fields, domain.FieldError{
+				Field:   name,
+				Source:  source,
+				Path:    name,
+				Message: err.Error(),
+			})
+		}
+	}
+	return nil
+}
+
+// Reports whether values of field type t can be bound from path, query, header or form
+// values, see setValue
+func bindable(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Slice && (t.Elem().Kind() == reflect.Uint8 || !reflect.PtrTo(t).Implements(textUnmarshaler)) {
+		t = t.Elem()
+	}
+	if t == timeType || reflect.PtrTo(t).Implements(textUnmarshaler) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// Unsupported field types of tagged fields in struct t, reported by Router.Validate
+func bindingErrors(t reflect.Type) []error {
+	var errs []error
+	for n := 0; n < t.NumField(); n++ {
+		field := t.Field(n)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			errs = append(errs, bindingErrors(field.Type)...)
+			continue
+		}
+		source, _ := bindingTag(field)
+		if field.PkgPath != "" || source == "" || (source == SourceForm && (field.Type == fileType || field.Type == filesType)) {
+			continue
+		}
+		if !bindable(field.Type) {
+			errs = append(errs, fmt.Errorf("field %s: unsupported %s field type %s", field.Name, source, field.Type))
+		}
+	}
+	return errs
+}
+
+func bindingTag(field reflect.StructField) (string, string) {
+	for _, source := range []string{SourcePath, SourceQuery, SourceHeader, SourceForm} {
+		if name, ok := field.Tag.Lookup(source); ok && name != "-" {
+			if name == "" {
+				name = field.Name
+			}
+			return source, name
+		}
+	}
+	return "", ""
+}
+
+func (i *Input) sourceValues(source string, name string) []string {
+	switch source {
+	case SourcePath:
+		if i.HasPathParam(name) {
+			return []string{i.GetPathParam(name)}
+		}
+	case SourceQuery:
+		return i.GetQueryParams(name)
+	case SourceHeader:
+		return i.GetHeaderValues(name)
+	case SourceForm:
+		if i.form != nil {
+			return i.form.Values[name]
+		}
+	}
+	return nil
+}
+
+func (i *Input) bindFiles(v reflect.Value, name string) {
+	if i.form == nil || len(i.form.Files[name]) == 0 {
+		return
+	}
+	if v.Type() == fileType {
+		v.Set(reflect.ValueOf(i.form.Files[name][0]))
+		return
+	}
+	v.Set(reflect.ValueOf(i.form.Files[name]))
+}
+
+func setValue(v reflect.Value, values []string) error {
+	if v.Kind() == reflect.Ptr {
+		ptr := reflect.New(v.Type().Elem())
+		if err := setValue(ptr.Elem(), values); err != nil {
+			return err
+		}
+		v.Set(ptr)
+		return nil
+	}
+
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+		v.SetBytes([]byte(values[len(values)-1]))
+		return nil
+	}
+	if v.Kind() == reflect.Slice && !v.Addr().Type().Implements(textUnmarshaler) {
+		if len(values) == 1 {
+			values = strings.Split(values[0], ",")
+		}
+		slice := reflect.MakeSlice(v.Type(), len(values), len(values))
+		for n, value := range values {
+			if err := setScalar(slice.Index(n), strings.TrimSpace(value)); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	}
+	return setScalar(v, values[len(values)-1])
+}
+
+func setScalar(v reflect.Value, value string) error {
+	if _, ok := v.Interface().(time.Time); ok {
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02"} {
+			if t, err := time.Parse(layout, value); err == nil {
+				v.Set(reflect.ValueOf(t))
+				return nil
+			}
+		}
+		return fmt.Errorf("must be an RFC 3339 date-time or date")
+	}
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshaler) {
+		if err := v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value)); err != nil {
+			return fmt.Errorf("invalid value: %v", err)
+		}
+		return nil
+	}
+
+	switch v.Interface().(type) {
+	case time.Duration:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("must be a duration")
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("must be a boolean")
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("must be an integer")
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("must be a positive integer")
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("must be a number")
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
+
+func typeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
@@ -0,0 +1,171 @@
+package http
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"strings"
+)
+
+// Media types decoded by Input
+const (
+	MediaTypeJSON      = "application/json"
+	MediaTypeForm      = "application/x-www-form-urlencoded"
+	MediaTypeMultipart = "multipart/form-data"
+)
+
+// Body decoding errors, ErrUnsupportedMediaType is returned by form methods for other
+// media types and ErrBodyTooLarge is responded as 413 by the router
+var (
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	ErrBodyTooLarge         = errors.New("request body too large")
+)
+
+// File part of a multipart/form-data body
+type File struct {
+	Field       string
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// Form values and files of a form body
+type Form struct {
+	Values url.Values
+	Files  map[string][]*File
+}
+
+// ContentType media type of request body without parameters, lowercased
+func (i *Input) ContentType() string {
+	header := i.GetHeader("Content-Type")
+	if header == "" {
+		return ""
+	}
+	mediaType, _, err := mime.ParseMediaType(header)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(strings.Split(header, ";")[0]))
+	}
+	return mediaType
+}
+
+// Body of request, base64 decoded when isBase64Encoded is set. Returns ErrBodyTooLarge
+// when the decoded body exceeds the router's max body size
+func (i *Input) Body() ([]byte, error) {
+	body, _ := i.event["body"].(string)
+	if body == "" {
+		return []byte(""), nil
+	}
+
+	decoded := []byte(body)
+	if encoded, _ := i.event["isBase64Encoded"].(bool); encoded {
+		var err error
+		if decoded, err = base64.StdEncoding.DecodeString(body); err != nil {
+			return nil, errors.New("could not decode base64 body")
+		}
+	}
+	if i.maxBodySize > 0 && int64(len(decoded)) > i.maxBodySize {
+		return nil, ErrBodyTooLarge
+	}
+	return decoded, nil
+}
+
+// Form values of an application/x-www-form-urlencoded or multipart/form-data body
+func (i *Input) Form() (url.Values, error) {
+	form, err := i.parseForm()
+	if err != nil {
+		return nil, err
+	}
+	return form.Values, nil
+}
+
+// MultipartForm values and files of a multipart/form-data body
+func (i *Input) MultipartForm() (*Form, error) {
+	if i.ContentType() != MediaTypeMultipart {
+		return nil, ErrUnsupportedMediaType
+	}
+	return i.parseForm()
+}
+
+// FormFile first file uploaded in given multipart field
+func (i *Input) FormFile(field string) (*File, error) {
+	form, err := i.MultipartForm()
+	if err != nil {
+		return nil, err
+	}
+	files := form.Files[field]
+	if len(files) == 0 {
+		return nil, errors.New("missing file " + field)
+	}
+	return files[0], nil
+}
+
+// Parses form body once per Input
+func (i *Input) parseForm() (*Form, error) {
+	if i.form != nil {
+		return i.form, nil
+	}
+
+	body, err := i.Body()
+	if err != nil {
+		return nil, err
+	}
+
+	form := &Form{Values: url.Values{}, Files: map[string][]*File{}}
+	switch i.ContentType() {
+	case MediaTypeForm:
+		if form.Values, err = url.ParseQuery(string(body)); err != nil {
+			return nil, errors.New("could not parse body as form")
+		}
+	case MediaTypeMultipart:
+		if err := i.parseMultipart(body, form); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrUnsupportedMediaType
+	}
+	i.form = form
+	return form, nil
+}
+
+func (i *Input) parseMultipart(body []byte, form *Form) error {
+	_, params, err := mime.ParseMediaType(i.GetHeader("Content-Type"))
+	if err != nil || params["boundary"] == "" {
+		return errors.New("multipart boundary missing in Content-Type")
+	}
+
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.New("could not parse body as multipart form")
+		}
+
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return errors.New("could not parse body as multipart form")
+		}
+		name := part.FormName()
+		if part.FileName() == "" {
+			form.Values.Add(name, string(content))
+			continue
+		}
+		form.Files[name] = append(form.Files[name], &File{
+			Field:       name,
+			Filename:    part.FileName(),
+			ContentType: part.Header.Get("Content-Type"),
+			Content:     content,
+		})
+	}
+}
+
+// JSON media types, including structured syntax suffixes like application/problem+json
+func isJSON(mediaType string) bool {
+	return mediaType == "" || mediaType == MediaTypeJSON || strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,135 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+)
+
+var pathParam = regexp.MustCompile(`\{[^}]*\}`)
+
+// Builder for Routes with nested groups sharing a path prefix, middleware and policies.
+// Groups inherit middleware and policies from their parents, including those added after
+// the group was created
+type Builder struct {
+	parent     *Builder
+	prefix     string
+	middleware []Middleware
+	policies   []domain.Policy
+	table      *routeTable
+}
+
+type routeTable struct {
+	routes []registration
+}
+
+type registration struct {
+	group  *Builder
+	method string
+	path   string
+	route  Route
+}
+
+// NewBuilder initializer
+func NewBuilder() *Builder {
+	return &Builder{table: &routeTable{}}
+}
+
+// Group sharing prefix, middleware and policies of b
+func (b *Builder) Group(prefix string) *Builder {
+	return &Builder{parent: b, prefix: joinPath(b.prefix, prefix), table: b.table}
+}
+
+// Use middleware for every route in group, run before the route's own middleware
+func (b *Builder) Use(middleware ...Middleware) *Builder {
+	b.middleware = append(b.middleware, middleware...)
+	return b
+}
+
+// Access required for every route in group, nil is ignored
+func (b *Builder) Access(access *domain.Access) *Builder {
+	if access == nil {
+		return b
+	}
+	return b.Policy(access)
+}
+
+// Policy required for every route in group, in addition to the route's own policy. Nil
+// policies are ignored
+func (b *Builder) Policy(policies ...domain.Policy) *Builder {
+	for _, policy := range policies {
+		if policy != nil {
+			b.policies = append(b.policies, policy)
+		}
+	}
+	return b
+}
+
+// Handle route for method on path relative to group prefix
+func (b *Builder) Handle(method string, path string, route Route) *Builder {
+	b.table.routes = append(b.table.routes, registration{
+		group:  b,
+		method: method,
+		path:   joinPath(b.prefix, path),
+		route:  route,
+	})
+	return b
+}
+
+// Build Routes from every group, reporting duplicate registrations and templates that
+// only differ in path param names
+func (b *Builder) Build() (Routes, error) {
+	routes := Routes{}
+	templates := map[string]string{}
+	var errs []error
+
+	for _, reg := range b.table.routes {
+		if _, ok := routes[reg.path][reg.method]; ok {
+			errs = append(errs, fmt.Errorf("duplicate route %s %s", reg.method, reg.path))
+			continue
+		}
+		shape := pathParam.ReplaceAllString(reg.path, "{}")
+		if existing, ok := templates[shape]; ok && existing != reg.path {
+			errs = append(errs, fmt.Errorf("route %s %s conflicts with %s", reg.method, reg.path, existing))
+			continue
+		}
+		templates[shape] = reg.path
+
+		if routes[reg.path] == nil {
+			routes[reg.path] = map[string]Route{}
+		}
+		routes[reg.path][reg.method] = reg.group.inherit(reg.route)
+	}
+	return routes, errors.Join(errs...)
+}
+
+// Prepends middleware and combines policies of group and its parents with route
+func (b *Builder) inherit(route Route) Route {
+	var middleware []Middleware
+	var policies []domain.Policy
+	for g := b; g != nil; g = g.parent {
+		middleware = append(append([]Middleware{}, g.middleware...), middleware...)
+		policies = append(append([]domain.Policy{}, g.policies...), policies...)
+	}
+	if len(middleware) > 0 {
+		route.Middleware = append(middleware, route.Middleware...)
+	}
+	if len(policies) > 0 {
+		if route.Policy != nil {
+			policies = append(policies, route.Policy)
+		}
+		route.Policy = domain.All(policies...)
+	}
+	return route
+}
+
+func joinPath(prefix string, path string) string {
+	joined := strings.TrimRight(prefix, "/") + "/" + strings.Trim(path, "/")
+	if joined != "/" {
+		joined = strings.TrimRight(joined, "/")
+	}
+	return joined
+}
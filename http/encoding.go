@@ -0,0 +1,169 @@
+package http
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Media types encoded by DefaultEncoders
+const (
+	MediaTypeProblemJSON = "application/problem+json"
+	MediaTypeCSV         = "text/csv"
+	MediaTypeXML         = "application/xml"
+)
+
+// Encoder for response bodies of a media type
+type Encoder interface {
+	Encode(body interface{}) ([]byte, error)
+}
+
+// EncoderFunc function as Encoder
+type EncoderFunc func(body interface{}) ([]byte, error)
+
+// Encode by calling function
+func (f EncoderFunc) Encode(body interface{}) ([]byte, error) {
+	return f(body)
+}
+
+// Encoders registry keyed by media type
+type Encoders map[string]Encoder
+
+// DefaultEncoders for JSON, problem+json, CSV and XML
+func DefaultEncoders() Encoders {
+	return Encoders{
+		MediaTypeJSON:        EncoderFunc(json.Marshal),
+		MediaTypeProblemJSON: EncoderFunc(json.Marshal),
+		MediaTypeCSV:         EncoderFunc(encodeCSV),
+		MediaTypeXML:         EncoderFunc(xml.Marshal),
+	}
+}
+
+// Accepts best media type among offers for the Accept header, the first offer is used
+// when the header is missing. Returns an empty string when no offer is acceptable
+func (i *Input) Accepts(offers ...string) string {
+	return negotiate(i.GetHeader("Accept"), offers)
+}
+
+type mediaRange struct {
+	mediaType string
+	quality   float64
+}
+
+func negotiate(accept string, offers []string) string {
+	if len(offers) == 0 {
+		return ""
+	}
+	if strings.TrimSpace(accept) == "" {
+		return offers[0]
+	}
+
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, err := strconv.ParseFloat(params["q"], 64); err == nil {
+			quality = q
+		}
+		ranges = append(ranges, mediaRange{mediaType: mediaType, quality: quality})
+	}
+
+	best, bestQuality := "", 0.0
+	for _, offer := range offers {
+		if q := offerQuality(strings.ToLower(offer), ranges); q > bestQuality {
+			best, bestQuality = offer, q
+		}
+	}
+	return best
+}
+
+// Quality of offer from the most specific matching media range
+func offerQuality(offer string, ranges []mediaRange) float64 {
+	quality, specificity := 0.0, -1
+	for _, r := range ranges {
+		s := -1
+		switch {
+		case r.mediaType == offer:
+			s = 2
+		case strings.HasSuffix(r.mediaType, "/*") && strings.HasPrefix(offer, strings.TrimSuffix(r.mediaType, "*")):
+			s = 1
+		case r.mediaType == "*/*":
+			s = 0
+		}
+		if s > specificity {
+			quality, specificity = r.quality, s
+		}
+	}
+	return quality
+}
+
+// Encodes strings and bytes as is, [][]string as rows and slices of structs with a
+// header row taken from csv tags or field names
+func encodeCSV(body interface{}) ([]byte, error) {
+	switch v := body.(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case [][]string:
+		return writeCSV(v)
+	}
+
+	value := reflect.ValueOf(body)
+	if value.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("csv: unsupported type %T", body)
+	}
+	elemType := value.Type().Elem()
+	for elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("csv: unsupported type %T", body)
+	}
+
+	var header []string
+	var fields []int
+	for n := 0; n < elemType.NumField(); n++ {
+		field := elemType.Field(n)
+		name := strings.Split(field.Tag.Get("csv"), ",")[0]
+		if field.PkgPath != "" || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		header = append(header, name)
+		fields = append(fields, n)
+	}
+
+	rows := [][]string{header}
+	for n := 0; n < value.Len(); n++ {
+		elem := reflect.Indirect(value.Index(n))
+		row := make([]string, len(fields))
+		if elem.IsValid() {
+			for c, f := range fields {
+				row[c] = fmt.Sprint(elem.Field(f).Interface())
+			}
+		}
+		rows = append(rows, row)
+	}
+	return writeCSV(rows)
+}
+
+func writeCSV(rows [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,167 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+)
+
+// Error typed HTTP error, returned by handlers as domain.Response or error and responded
+// in the router's error format
+type Error struct {
+	Status int
+	// Type URI reference identifying the problem, defaults to about:blank
+	Type string
+	// Title short summary, defaults to the status text
+	Title  string
+	Detail string
+	// Extensions additional members, e.g. errors for field errors
+	Extensions map[string]interface{}
+	// Err underlying cause, logged but never responded
+	Err error
+}
+
+// NewError initializer
+func NewError(status int, detail string) *Error {
+	return &Error{Status: status, Detail: detail}
+}
+
+// BadRequest 400 error
+func BadRequest(detail string) *Error {
+	return NewError(http.StatusBadRequest, detail)
+}
+
+// Unauthorized 401 error
+func Unauthorized(detail string) *Error {
+	return NewError(http.StatusUnauthorized, detail)
+}
+
+// Forbidden 403 error
+func Forbidden(detail string) *Error {
+	return NewError(http.StatusForbidden, detail)
+}
+
+// NotFound 404 error
+func NotFound(detail string) *Error {
+	return NewError(http.StatusNotFound, detail)
+}
+
+// Conflict 409 error
+func Conflict(detail string) *Error {
+	return NewError(http.StatusConflict, detail)
+}
+
+// Validation 422 error with invalid fields as errors extension
+func Validation(detail string, fields []domain.FieldError) *Error {
+	return NewError(http.StatusUnprocessableEntity, detail).With("errors", fields)
+}
+
+// Internal 500 error wrapping cause, responded without any detail
+func Internal(err error) *Error {
+	return &Error{Status: http.StatusInternalServerError, Err: err}
+}
+
+// With extension member
+func (e *Error) With(key string, value interface{}) *Error {
+	if e.Extensions == nil {
+		e.Extensions = map[string]interface{}{}
+	}
+	e.Extensions[key] = value
+	return e
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("%d %s", e.Status, e.title())
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap underlying cause
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Payload formatted as problem details, the router formats errors with its ErrorFormatter
+func (e *Error) Payload() interface{} {
+	return ProblemFormatter(nil, e).Payload()
+}
+
+func (e *Error) title() string {
+	if e.Title != "" {
+		return e.Title
+	}
+	return http.StatusText(e.Status)
+}
+
+// ErrorFormatter maps typed errors to responses
+type ErrorFormatter func(i *Input, err *Error) *Response
+
+// ProblemFormatter RFC 7807 application/problem+json response with the request id as
+// instance. Detail is omitted for 5xx errors
+func ProblemFormatter(i *Input, err *Error) *Response {
+	problem := map[string]interface{}{}
+	for k, v := range err.Extensions {
+		problem[k] = v
+	}
+	problem["type"] = err.Type
+	if err.Type == "" {
+		problem["type"] = "about:blank"
+	}
+	problem["title"] = err.title()
+	problem["status"] = err.Status
+	if err.Detail != "" && err.Status < http.StatusInternalServerError {
+		problem["detail"] = err.Detail
+	}
+	if i != nil && i.RequestID() != "" {
+		problem["instance"] = i.RequestID()
+	}
+
+	res := NewResponse(err.Status, problem)
+	res.SetContentType(MediaTypeProblemJSON)
+	return res
+}
+
+// LegacyFormatter {"error": detail} response as returned by NewErrorResponse, field
+// errors are responded as {"error": {"message": detail, "fields": errors}}
+func LegacyFormatter(i *Input, err *Error) *Response {
+	message := err.Detail
+	if message == "" || err.Status >= http.StatusInternalServerError {
+		message = err.title()
+	}
+	if fields, ok := err.Extensions["errors"]; ok {
+		return NewErrorResponse(err.Status, map[string]interface{}{"message": message, "fields": fields})
+	}
+	return NewErrorResponse(err.Status, message)
+}
+
+// Formats err as typed Error, unknown errors become an internal error
+func (r *Router) errorResponse(i *Input, err error) *Response {
+	var typed *Error
+	if !errors.As(err, &typed) {
+		typed = Internal(err)
+	}
+	if typed.Status >= http.StatusInternalServerError {
+		i.logger.Error("Router::Route() handler failed", domain.Fields{"error": typed.Error()})
+	}
+
+	res := r.errorFormatter(i, typed)
+	if res.encoded {
+		return res
+	}
+	mediaType := res.contentType
+	if mediaType == "" {
+		mediaType = MediaTypeJSON
+	}
+	if encoder := r.encoder(mediaType); encoder == nil || res.encode(mediaType, encoder) != nil {
+		i.logger.Error("Router::Route() could not encode error response", domain.Fields{"contentType": mediaType})
+		return r.internalError()
+	}
+	return res
+}
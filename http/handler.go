@@ -0,0 +1,35 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+)
+
+// HandlerFunc returning a body encoded by the router, or an error mapped by the router's
+// ErrorFormatter. Errors other than *Error are responded as a sanitized 500
+type HandlerFunc func(i *Input) (interface{}, error)
+
+// Handle adapts fn to a Route Handler responding status on success, e.g.
+// Handle(http.StatusCreated, createOrder). Bodies that are a domain.Response are
+// responded as is
+func Handle(status int, fn HandlerFunc) func(i *Input) domain.Response {
+	if status == 0 {
+		status = http.StatusOK
+	}
+	return func(i *Input) domain.Response {
+		body, err := fn(i)
+		if err != nil {
+			var typed *Error
+			if errors.As(err, &typed) {
+				return typed
+			}
+			return Internal(err)
+		}
+		if res, ok := body.(domain.Response); ok {
+			return res
+		}
+		return NewResponse(status, body)
+	}
+}
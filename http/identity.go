@@ -0,0 +1,114 @@
+package http
+
+import "fmt"
+
+// Identity of the caller from requestContext.identity, or requestContext.authorizer.iam
+// for HTTP API IAM authorization
+type Identity struct {
+	SourceIP                      string
+	UserAgent                     string
+	User                          string
+	UserARN                       string
+	Caller                        string
+	AccountID                     string
+	AccessKey                     string
+	APIKey                        string
+	APIKeyID                      string
+	CognitoIdentityID             string
+	CognitoIdentityPoolID         string
+	CognitoAuthenticationType     string
+	CognitoAuthenticationProvider string
+}
+
+// Identity of the caller, nil when the event carries no identity
+func (i *Input) Identity() *Identity {
+	reqContext, _ := i.event["requestContext"].(map[string]interface{})
+	if identity, ok := reqContext["identity"].(map[string]interface{}); ok {
+		return &Identity{
+			SourceIP:                      stringValue(identity["sourceIp"]),
+			UserAgent:                     stringValue(identity["userAgent"]),
+			User:                          stringValue(identity["user"]),
+			UserARN:                       stringValue(identity["userArn"]),
+			Caller:                        stringValue(identity["caller"]),
+			AccountID:                     stringValue(identity["accountId"]),
+			AccessKey:                     stringValue(identity["accessKey"]),
+			APIKey:                        stringValue(identity["apiKey"]),
+			APIKeyID:                      stringValue(identity["apiKeyId"]),
+			CognitoIdentityID:             stringValue(identity["cognitoIdentityId"]),
+			CognitoIdentityPoolID:         stringValue(identity["cognitoIdentityPoolId"]),
+			CognitoAuthenticationType:     stringValue(identity["cognitoAuthenticationType"]),
+			CognitoAuthenticationProvider: stringValue(identity["cognitoAuthenticationProvider"]),
+		}
+	}
+
+	authorizer, _ := reqContext["authorizer"].(map[string]interface{})
+	iam, ok := authorizer["iam"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	cognito, _ := iam["cognitoIdentity"].(map[string]interface{})
+	return &Identity{
+		User:                  stringValue(iam["userId"]),
+		UserARN:               stringValue(iam["userArn"]),
+		Caller:                stringValue(iam["callerId"]),
+		AccountID:             stringValue(iam["accountId"]),
+		AccessKey:             stringValue(iam["accessKey"]),
+		CognitoIdentityID:     stringValue(cognito["identityId"]),
+		CognitoIdentityPoolID: stringValue(cognito["identityPoolId"]),
+	}
+}
+
+// APIKey used for the request, empty when API keys are not required
+func (i *Input) APIKey() string {
+	if identity := i.Identity(); identity != nil {
+		return identity.APIKey
+	}
+	return ""
+}
+
+// AuthorizerContext set by a Lambda authorizer. REST APIs put context keys directly
+// under authorizer while HTTP APIs nest them under authorizer.lambda
+func (i *Input) AuthorizerContext() map[string]interface{} {
+	reqContext, _ := i.event["requestContext"].(map[string]interface{})
+	authorizer, ok := reqContext["authorizer"].(map[string]interface{})
+	if !ok {
+		return map[string]interface{}{}
+	}
+	if lambda, ok := authorizer["lambda"].(map[string]interface{}); ok {
+		return lambda
+	}
+
+	out := make(map[string]interface{}, len(authorizer))
+	for k, v := range authorizer {
+		if k == "claims" || k == "jwt" || k == "iam" {
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// GetAuthorizerValue from Lambda authorizer context formatted as string
+func (i *Input) GetAuthorizerValue(key string) string {
+	value, ok := i.AuthorizerContext()[key]
+	if !ok || value == nil {
+		return ""
+	}
+	return stringValue(value)
+}
+
+// PrincipalID returned by a Lambda authorizer
+func (i *Input) PrincipalID() string {
+	return i.GetAuthorizerValue("principalId")
+}
+
+func stringValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -1,20 +1,45 @@
 package http
 
 import (
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"strings"
+
 	"github.com/matthisstenius/lambda-router/v4/domain"
-	"github.com/matthisstenius/logger"
+	"github.com/matthisstenius/lambda-router/v4/logging"
 )
 
 // Input for parsed HTTP event
 type Input struct {
-	event map[string]interface{}
+	event       map[string]interface{}
+	logger      domain.Logger
+	ctx         context.Context
+	verifier    domain.TokenVerifier
+	request     interface{}
+	form        *Form
+	maxBodySize int64
 }
 
 // NewInput initializer
 func NewInput(e map[string]interface{}) *Input {
-	return &Input{event: e}
+	return &Input{event: e, logger: logging.Default(), ctx: context.Background()}
+}
+
+// Context for current invocation
+func (i *Input) Context() context.Context {
+	return i.ctx
+}
+
+// Request bound for routes with Request set, a pointer to the route's Request type
+func (i *Input) Request() interface{} {
+	return i.request
+}
+
+// Logger for current event
+func (i *Input) Logger() domain.Logger {
+	return i.logger
 }
 
 // GetPathParam in current request
@@ -37,6 +62,18 @@ func (i *Input) GetPathParam(param string) string {
 	}
 }
 
+func (i *Input) pathParams() map[string]string {
+	out := map[string]string{}
+	params, ok := i.event["pathParameters"].(map[string]interface{})
+	if !ok {
+		return out
+	}
+	for k := range params {
+		out[k] = i.GetPathParam(k)
+	}
+	return out
+}
+
 // HasPathParam checks if param exists in path params
 func (i *Input) HasPathParam(param string) bool {
 	params, ok := i.event["pathParameters"]
@@ -83,18 +120,22 @@ func (i *Input) GetQueryParam(param string) string {
 	}
 }
 
-// GetHeader in current request
+// GetHeader in current request, matched case-insensitively
 func (i *Input) GetHeader(header string) string {
-	params, ok := i.event["headers"]
-	if !ok || params == nil {
-		return ""
+	headers, _ := i.event["headers"].(map[string]interface{})
+	if value, ok := headers[header].(string); ok {
+		return value
+	}
+	for k, v := range headers {
+		if value, ok := v.(string); ok && strings.EqualFold(k, header) {
+			return value
+		}
 	}
 
-	value, ok := params.(map[string]interface{})[header].(string)
-	if !ok {
-		return ""
+	if values := i.multiValue("multiValueHeaders", header, true); len(values) > 0 {
+		return values[len(values)-1]
 	}
-	return value
+	return ""
 }
 
 // ParseQueryParam in current request as JSON
@@ -105,29 +146,66 @@ func (i *Input) ParseQueryParam(param string, out interface{}) error {
 	return nil
 }
 
-// ParseBody in current request
+// ParseBody in current request as JSON whatever its content type, use Route.Consumes to
+// restrict media types
 func (i *Input) ParseBody(out interface{}) error {
-	body, ok := i.event["body"]
-	if !ok || body == nil {
+	if body, ok := i.event["body"]; !ok || body == nil {
 		return errors.New("missing request body")
 	}
 
-	if err := json.Unmarshal([]byte(body.(string)), &out); err != nil {
+	body, err := i.Body()
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, &out); err != nil {
 		return errors.New("could not parse body as JSON")
 	}
 	return nil
 }
 
-// Auth get auth properties based on given AuthProvider
+// Auth get auth properties based on given AuthProvider. Falls back to verifying the
+// Authorization bearer token when the router has a TokenVerifier and the authorizer
+// provided no claims
 func (i *Input) Auth() (*domain.AuthClaims, error) {
-	reqContext := i.event["requestContext"]
-	authorizer, ok := reqContext.(map[string]interface{})["authorizer"]
-	if !ok || authorizer == nil {
-		return nil, errors.New("authorizer index missing in event")
+	claims, err := i.authorizerClaims()
+	if err != nil && i.verifier != nil {
+		return i.bearerClaims()
+	}
+	return claims, err
+}
+
+func (i *Input) bearerClaims() (*domain.AuthClaims, error) {
+	header := i.GetHeader("Authorization")
+	if len(header) < 7 || !strings.EqualFold(header[:7], "Bearer ") {
+		return nil, errors.New("bearer token missing in Authorization header")
+	}
+
+	claims, err := i.verifier.Verify(strings.TrimSpace(header[7:]))
+	if err != nil {
+		i.logger.Info("Input::Auth() Could not verify bearer token", domain.Fields{
+			"error": err,
+		})
+		return nil, errors.New("invalid bearer token")
 	}
+	return domain.NewAuthClaims(claims), nil
+}
 
-	claims, ok := authorizer.(map[string]interface{})["claims"]
+func (i *Input) authorizerClaims() (*domain.AuthClaims, error) {
+	reqContext, ok := i.event["requestContext"].(map[string]interface{})
 	if !ok {
+		return nil, errors.New("requestContext index missing in event")
+	}
+	authorizer, ok := reqContext["authorizer"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("authorizer index missing in event")
+	}
+
+	// HTTP API JWT authorizers nest claims under jwt
+	if jwt, ok := authorizer["jwt"].(map[string]interface{}); ok {
+		authorizer = jwt
+	}
+	claims, ok := authorizer["claims"]
+	if !ok || claims == nil {
 		return nil, errors.New("claims index missing in authorizer")
 	}
 
@@ -135,22 +213,42 @@ func (i *Input) Auth() (*domain.AuthClaims, error) {
 	if value, ok := claims.(string); ok {
 		err := json.Unmarshal([]byte(value), &authProps)
 		if err != nil {
-			logger.WithFields(logger.Fields{
+			i.logger.Error("CognitoAuthProvider::ParseAuth() Could not parse claims as JSON", domain.Fields{
 				"error": err,
-			}).Error("CognitoAuthProvider::ParseAuth() Could not parse claims as JSON")
+			})
 			return nil, errors.New("could not parse claims as JSON")
 		}
+	} else if value, ok := claims.(map[string]interface{}); ok {
+		authProps = value
 	} else {
-		authProps = claims.(map[string]interface{})
+		return nil, errors.New("could not parse claims as JSON")
 	}
 	return domain.NewAuthClaims(authProps), nil
 }
 
-// RawBody get raw body form event
+// RawBody get raw body form event, base64 decoded when isBase64Encoded is set
 func (i *Input) RawBody() []byte {
 	body, ok := i.event["body"]
 	if !ok || body == nil {
 		return []byte("")
 	}
+	if encoded, _ := i.event["isBase64Encoded"].(bool); encoded {
+		if decoded, err := base64.StdEncoding.DecodeString(body.(string)); err == nil {
+			return decoded
+		}
+	}
 	return []byte(body.(string))
 }
+
+// CorrelationID from X-Correlation-Id header, falls back to API Gateway request id
+func (i *Input) CorrelationID() string {
+	if id := i.GetHeader(domain.CorrelationIDHeader); id != "" {
+		return id
+	}
+	if reqContext, ok := i.event["requestContext"].(map[string]interface{}); ok {
+		if id, ok := reqContext["requestId"].(string); ok {
+			return id
+		}
+	}
+	return ""
+}
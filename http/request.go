@@ -0,0 +1,190 @@
+package http
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Matches path templates such as {id} and greedy {proxy+}
+var templateParam = regexp.MustCompile(`{([^}+]+)\+?}`)
+
+func (i *Input) requestContext() map[string]interface{} {
+	reqContext, _ := i.event["requestContext"].(map[string]interface{})
+	return reqContext
+}
+
+// HTTP API payload version 2.0 keeps method, path and source ip under requestContext.http
+func (i *Input) httpContext() map[string]interface{} {
+	h, _ := i.requestContext()["http"].(map[string]interface{})
+	return h
+}
+
+// Method of current request for REST, HTTP API and ALB events
+func (i *Input) Method() string {
+	if method, ok := i.event["httpMethod"].(string); ok {
+		return method
+	}
+	return stringValue(i.httpContext()["method"])
+}
+
+// RequestID assigned by API Gateway
+func (i *Input) RequestID() string {
+	return stringValue(i.requestContext()["requestId"])
+}
+
+// Stage of the API
+func (i *Input) Stage() string {
+	return stringValue(i.requestContext()["stage"])
+}
+
+// APIID of the API
+func (i *Input) APIID() string {
+	return stringValue(i.requestContext()["apiId"])
+}
+
+// DomainName request was sent to, falls back to Host header for ALB events
+func (i *Input) DomainName() string {
+	if domain := stringValue(i.requestContext()["domainName"]); domain != "" {
+		return domain
+	}
+	return i.GetHeader("Host")
+}
+
+// Protocol of current request, e.g. HTTP/1.1
+func (i *Input) Protocol() string {
+	if protocol := stringValue(i.requestContext()["protocol"]); protocol != "" {
+		return protocol
+	}
+	return stringValue(i.httpContext()["protocol"])
+}
+
+// SourceIP of the client, falls back to first X-Forwarded-For address for ALB events
+func (i *Input) SourceIP() string {
+	if identity := i.Identity(); identity != nil && identity.SourceIP != "" {
+		return identity.SourceIP
+	}
+	if ip := stringValue(i.httpContext()["sourceIp"]); ip != "" {
+		return ip
+	}
+	forwarded := strings.Split(i.GetHeader("X-Forwarded-For"), ",")
+	return strings.TrimSpace(forwarded[0])
+}
+
+// UserAgent of the client
+func (i *Input) UserAgent() string {
+	if identity := i.Identity(); identity != nil && identity.UserAgent != "" {
+		return identity.UserAgent
+	}
+	if agent := stringValue(i.httpContext()["userAgent"]); agent != "" {
+		return agent
+	}
+	return i.GetHeader("User-Agent")
+}
+
+// RequestTimeEpoch in milliseconds, 0 when unknown
+func (i *Input) RequestTimeEpoch() int64 {
+	for _, key := range []string{"requestTimeEpoch", "timeEpoch"} {
+		switch v := i.requestContext()[key].(type) {
+		case float64:
+			return int64(v)
+		case int64:
+			return v
+		case int:
+			return int64(v)
+		case string:
+			epoch, _ := strconv.ParseInt(v, 10, 64)
+			return epoch
+		}
+	}
+	return 0
+}
+
+// RequestTime of current request, zero time when unknown
+func (i *Input) RequestTime() time.Time {
+	epoch := i.RequestTimeEpoch()
+	if epoch == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, epoch*int64(time.Millisecond)).UTC()
+}
+
+// StageVariables of the API stage
+func (i *Input) StageVariables() map[string]string {
+	out := map[string]string{}
+	variables, _ := i.event["stageVariables"].(map[string]interface{})
+	for k, v := range variables {
+		out[k] = stringValue(v)
+	}
+	return out
+}
+
+// GetStageVariable by name
+func (i *Input) GetStageVariable(name string) string {
+	return i.StageVariables()[name]
+}
+
+// RawPath as received, including stage and base path mapping prefixes
+func (i *Input) RawPath() string {
+	if path, ok := i.event["rawPath"].(string); ok {
+		return path
+	}
+	return stringValue(i.event["path"])
+}
+
+// Path relative to the API root with stage and base path mapping stripped. Resolved from
+// the resource template and path params when available
+func (i *Input) Path() string {
+	template := stringValue(i.event["resource"])
+	if template == "" {
+		if routeKey := strings.SplitN(stringValue(i.event["routeKey"]), " ", 2); len(routeKey) == 2 {
+			template = routeKey[1]
+		}
+	}
+	if template != "" {
+		return templateParam.ReplaceAllStringFunc(template, func(param string) string {
+			return i.GetPathParam(templateParam.FindStringSubmatch(param)[1])
+		})
+	}
+
+	path := i.RawPath()
+	if stage := i.Stage(); stage != "" && stage != "$default" {
+		path = strings.TrimPrefix(path, "/"+stage)
+	}
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+// URL of current request reconstructed from domain name, path and query string
+func (i *Input) URL() *url.URL {
+	scheme := strings.ToLower(i.GetHeader("X-Forwarded-Proto"))
+	if scheme == "" {
+		scheme = "https"
+	}
+
+	u := &url.URL{Scheme: scheme, Host: i.DomainName(), Path: i.Path()}
+	if raw, ok := i.event["rawQueryString"].(string); ok {
+		u.RawQuery = raw
+		return u
+	}
+
+	query := url.Values{}
+	if multi, ok := i.event["multiValueQueryStringParameters"].(map[string]interface{}); ok {
+		for k, values := range multi {
+			list, _ := values.([]interface{})
+			for _, v := range list {
+				query.Add(k, stringValue(v))
+			}
+		}
+	} else if single, ok := i.event["queryStringParameters"].(map[string]interface{}); ok {
+		for k, v := range single {
+			query.Set(k, stringValue(v))
+		}
+	}
+	u.RawQuery = query.Encode()
+	return u
+}
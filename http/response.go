@@ -2,19 +2,34 @@ package http
 
 import (
 	"encoding/json"
-	"github.com/matthisstenius/logger"
+	"net/http"
 )
 
 // Response for HTTP event
 type Response struct {
 	statusCode      int
-	body            interface{}
+	body            string
+	value           interface{}
+	contentType     string
+	encoded         bool
 	headers         map[string]string
 	isBase64Encoded bool
 }
 
-// Payload formatted response data
+// Payload formatted response data. Bodies not encoded by the router are encoded as JSON
 func (r *Response) Payload() interface{} {
+	if !r.encoded {
+		mediaType := r.contentType
+		if mediaType == "" {
+			mediaType = MediaTypeJSON
+		}
+		if err := r.encode(mediaType, EncoderFunc(json.Marshal)); err != nil {
+			failure := NewErrorResponse(http.StatusInternalServerError, "Internal server error")
+			_ = failure.encode(MediaTypeJSON, EncoderFunc(json.Marshal))
+			r.statusCode, r.body, r.encoded = failure.statusCode, failure.body, true
+		}
+	}
+
 	return map[string]interface{}{
 		"statusCode":      r.statusCode,
 		"body":            r.body,
@@ -23,14 +38,42 @@ func (r *Response) Payload() interface{} {
 	}
 }
 
-// NewResponse initialize success response
-func NewResponse(status int, body interface{}) *Response {
-	encoded, _ := json.Marshal(body)
-	logger.WithFields(logger.Fields{"body": string(encoded)}).Info("response")
+// SetHeader on response, overwriting any existing value
+func (r *Response) SetHeader(key string, value string) {
+	if r.headers == nil {
+		r.headers = map[string]string{}
+	}
+	r.headers[key] = value
+}
+
+// SetContentType of body, skipping content negotiation for this response
+func (r *Response) SetContentType(mediaType string) {
+	r.contentType = mediaType
+}
+
+// Encodes body with encoder and sets Content-Type, 204 responses have no body
+func (r *Response) encode(mediaType string, encoder Encoder) error {
+	if r.statusCode == http.StatusNoContent {
+		r.body = ""
+		r.encoded = true
+		return nil
+	}
+	encoded, err := encoder.Encode(r.value)
+	if err != nil {
+		return err
+	}
+	r.body = string(encoded)
+	r.encoded = true
+	r.SetHeader("Content-Type", mediaType)
+	return nil
+}
 
+// NewResponse initialize success response, the body is encoded by the router based on
+// the request's Accept header
+func NewResponse(status int, body interface{}) *Response {
 	return &Response{
 		statusCode: status,
-		body:       string(encoded),
+		value:      body,
 		headers: map[string]string{
 			"Access-Control-Allow-headers": "Content-Type,X-Amz-Date,Authorization,X-Api-Key",
 			"Access-Control-Allow-Methods": "*",
@@ -40,23 +83,11 @@ func NewResponse(status int, body interface{}) *Response {
 	}
 }
 
-// NewErrorResponse initialize error response
+// NewErrorResponse initialize error response, always encoded as JSON
 func NewErrorResponse(status int, error interface{}) *Response {
-	encoded, _ := json.Marshal(map[string]interface{}{
+	res := NewResponse(status, map[string]interface{}{
 		"error": error,
 	})
-	logger.WithFields(logger.Fields{
-		"error": string(encoded),
-	}).Info("Error response")
-
-	return &Response{
-		statusCode: status,
-		body:       string(encoded),
-		headers: map[string]string{
-			"Access-Control-Allow-headers": "Content-Type,X-Amz-Date,Authorization,X-Api-Key",
-			"Access-Control-Allow-Methods": "*",
-			"Access-Control-Allow-Origin":  "*",
-		},
-		isBase64Encoded: false,
-	}
+	res.contentType = MediaTypeJSON
+	return res
 }
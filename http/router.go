@@ -1,11 +1,17 @@
 package http
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/logging"
+	"github.com/matthisstenius/lambda-router/v4/validate"
 )
 
 type Middleware func(i *Input) domain.Response
@@ -14,7 +20,32 @@ type Middleware func(i *Input) domain.Response
 type Route struct {
 	Handler    func(i *Input) domain.Response
 	Access     *domain.Access
+	Policy     domain.Policy
 	Middleware []Middleware
+	// Request struct value bound from path, query, header and body and validated against
+	// its validate tags before calling Handler, available as a pointer through
+	// Input.Request(). Binding failures respond 400 and validation failures 422
+	Request interface{}
+	// Response body value documenting the route's success response, set by JSON
+	Response interface{}
+	// Status documenting the route's success response, set by JSON
+	Status int
+	// Doc summary, description and tags for generated API documentation
+	Doc *Doc
+	// Consumes media types accepted in request bodies, other types respond 415
+	Consumes []string
+	// Produces media types negotiated for response bodies, defaults to JSON. Other
+	// registered encoders such as XML and CSV are only offered when listed, problem+json
+	// is reserved for error responses
+	Produces []string
+}
+
+// Doc for generated API documentation
+type Doc struct {
+	Summary     string
+	Description string
+	Tags        []string
+	OperationID string
 }
 
 // Routes mappings for HTTP handlers
@@ -22,48 +53,233 @@ type Routes map[string]map[string]Route
 
 // Router for HTTP events
 type Router struct {
-	routes     Routes
-	middleware []Middleware
+	routes       Routes
+	middleware   []Middleware
+	logging      domain.Logging
+	logger       domain.Logger
+	customLogger bool
+	verifier     domain.TokenVerifier
+	maxBodySize  int64
+	encoders     Encoders
+	// templates of routes in resolution order
+	templates []string
+	// errorFormatter maps typed errors to responses
+	errorFormatter    ErrorFormatter
+	requestValidator  RequestValidator
+	responseValidator ResponseValidator
+}
+
+// Option for configuring Router
+type Option func(r *Router)
+
+// WithLogging levels for incoming events and outgoing responses
+func WithLogging(logging domain.Logging) Option {
+	return func(r *Router) {
+		r.logging = logging
+	}
+}
+
+// WithLogger for routing and handler logging, defaults to logging.Default()
+func WithLogger(logger domain.Logger) Option {
+	return func(r *Router) {
+		r.logger = logger
+		r.customLogger = true
+	}
+}
+
+// DefaultLogger replaces logging.Default() unless a logger was set with WithLogger, used
+// by router.Event for Config.Logger
+func (r *Router) DefaultLogger(logger domain.Logger) {
+	if !r.customLogger {
+		r.logger = logger
+	}
+}
+
+// WithTokenVerifier for verifying bearer tokens when no authorizer claims are present
+func WithTokenVerifier(verifier domain.TokenVerifier) Option {
+	return func(r *Router) {
+		r.verifier = verifier
+	}
+}
+
+// WithMaxBodySize in bytes after base64 decoding, larger bodies respond 413
+func WithMaxBodySize(size int64) Option {
+	return func(r *Router) {
+		r.maxBodySize = size
+	}
+}
+
+// WithEncoders for response media types, added to and overriding DefaultEncoders
+func WithEncoders(encoders Encoders) Option {
+	return func(r *Router) {
+		for mediaType, encoder := range encoders {
+			r.encoders[mediaType] = encoder
+		}
+	}
+}
+
+// WithErrorFormatter for typed errors, defaults to ProblemFormatter
+func WithErrorFormatter(formatter ErrorFormatter) Option {
+	return func(r *Router) {
+		r.errorFormatter = formatter
+	}
 }
 
 // NewRouter initializer
-func NewRouter(routes Routes, middleware []Middleware) *Router {
-	return &Router{routes: routes, middleware: middleware}
+func NewRouter(routes Routes, middleware []Middleware, opts ...Option) *Router {
+	r := &Router{
+		routes:     routes,
+		middleware: middleware,
+		logger:     logging.Default(),
+		encoders:   DefaultEncoders(),
+		// RFC 7807 problem details
+		errorFormatter: ProblemFormatter,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	for template := range routes {
+		r.templates = append(r.templates, template)
+	}
+	sort.Strings(r.templates)
+	return r
 }
 
 // Dispatch incoming event to corresponding handler
 func (r *Router) Route(evt map[string]interface{}) (domain.Response, error) {
-	pathParams, ok := evt["pathParameters"]
-	resource := evt["resource"].(string)
-	method := evt["httpMethod"].(string)
+	return r.RouteContext(context.Background(), evt)
+}
+
+// RouteContext routes incoming event to corresponding handler with invocation context
+func (r *Router) RouteContext(ctx context.Context, evt map[string]interface{}) (domain.Response, error) {
+	i := NewInput(evt)
+	i.ctx = ctx
+	i.verifier = r.verifier
+	i.maxBodySize = r.maxBodySize
+	correlationID := i.CorrelationID()
+	i.logger = r.logger.With(domain.Fields{domain.CorrelationIDField: correlationID})
 
-	if ok && pathParams != nil {
-		for k, v := range pathParams.(map[string]interface{}) {
-			resource = strings.Replace(resource, v.(string), fmt.Sprintf("{%s}", k), 1)
+	res := r.dispatch(evt, i)
+	if err, ok := res.(*Error); ok {
+		res = r.errorResponse(i, err)
+	}
+	if httpRes, ok := res.(*Response); ok {
+		route, _ := r.lookup(evt)
+		httpRes = r.encode(route, i, httpRes)
+		httpRes = r.validateResponse(evt, i, httpRes)
+		if correlationID != "" {
+			httpRes.SetHeader(domain.CorrelationIDHeader, correlationID)
 		}
+		res = httpRes
 	}
+	return res, nil
+}
 
-	route, ok := r.routes[resource][method]
+func (r *Router) lookup(evt map[string]interface{}) (Route, bool) {
+	route, ok := r.routes[r.resource(evt)][evt["httpMethod"].(string)]
+	return route, ok
+}
+
+func (r *Router) dispatch(evt map[string]interface{}, i *Input) domain.Response {
+	route, ok := r.lookup(evt)
 	if !ok {
-		return NewErrorResponse(http.StatusNotFound, "No matching handler found"), nil
+		return NotFound("No matching handler found")
 	}
 
-	i := NewInput(evt)
-	if !r.hasAccess(route.Access, i) {
-		return NewErrorResponse(http.StatusForbidden, "Access denied"), nil
+	if res := r.authorize(route, i); res != nil {
+		return res
+	}
+
+	if res := r.checkBody(route, i); res != nil {
+		return res
+	}
+
+	if res := r.validateRequest(evt, i); res != nil {
+		return res
+	}
+
+	if route.Request != nil {
+		req := reflect.New(reflect.TypeOf(route.Request))
+		if err := i.Bind(req.Interface()); err != nil {
+			bindErr, ok := err.(*BindError)
+			if !ok {
+				return Internal(fmt.Errorf("invalid route Request type: %w", err))
+			}
+			i.logger.Info("Router::Route() could not bind request", domain.Fields{"error": err.Error()})
+			return BadRequest(bindErr.Message).With("errors", bindErr.Fields)
+		}
+		if err := validate.Struct(req.Interface()); err != nil {
+			validationErr, ok := err.(*validate.Error)
+			if !ok {
+				return Internal(fmt.Errorf("invalid route Request validate tags: %w", err))
+			}
+			i.logger.Info("Router::Route() invalid request", domain.Fields{"error": err.Error()})
+			return Validation(validationErr.Message, validationErr.Fields)
+		}
+		i.request = req.Interface()
 	}
 
 	for _, m := range route.Middleware {
 		if res := m(i); res != nil {
-			return res, nil
+			return res
 		}
 	}
 	for _, m := range r.middleware {
 		if res := m(i); res != nil {
-			return res, nil
+			return res
+		}
+	}
+	return route.Handler(i)
+}
+
+// RouteKey HTTP method and resource template, e.g. GET /users/{id}
+func (r *Router) RouteKey(evt map[string]interface{}) string {
+	return fmt.Sprintf("%s %s", evt["httpMethod"].(string), r.resource(evt))
+}
+
+// Resolves resource template as the registered template whose path params match the
+// event's path segments, falling back to the event's resource
+func (r *Router) resource(evt map[string]interface{}) string {
+	resource := evt["resource"].(string)
+	pathParams, _ := evt["pathParameters"].(map[string]interface{})
+	if _, ok := r.routes[resource]; ok || len(pathParams) == 0 {
+		return resource
+	}
+
+	for _, template := range r.templates {
+		if matchTemplate(template, resource, pathParams) {
+			return template
+		}
+	}
+	return resource
+}
+
+// Matches literal segments of template against resource and params, e.g. {id} against
+// the id path param and greedy {proxy+} against the remaining segments
+func matchTemplate(template string, resource string, pathParams map[string]interface{}) bool {
+	templateSegments, segments := strings.Split(template, "/"), strings.Split(resource, "/")
+	for n, segment := range templateSegments {
+		if n >= len(segments) {
+			return false
+		}
+		if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+			if segment != segments[n] {
+				return false
+			}
+			continue
+		}
+
+		name := segment[1 : len(segment)-1]
+		if strings.HasSuffix(name, "+") {
+			value, _ := pathParams[strings.TrimSuffix(name, "+")].(string)
+			return n == len(templateSegments)-1 && strings.Join(segments[n:], "/") == value
+		}
+		if value, ok := pathParams[name].(string); !ok || value != segments[n] {
+			return false
 		}
 	}
-	return route.Handler(i), nil
+	return len(templateSegments) == len(segments)
 }
 
 // IsMatch for HTTP event
@@ -74,26 +290,113 @@ func (r *Router) IsMatch(e map[string]interface{}) bool {
 	return false
 }
 
-func (r *Router) hasAccess(access *domain.Access, i *Input) bool {
-	if access == nil {
-		return true
+// CorrelationID for HTTP event
+func (r *Router) CorrelationID(evt map[string]interface{}) string {
+	return NewInput(evt).CorrelationID()
+}
+
+// Logging levels for HTTP events
+func (r *Router) Logging() domain.Logging {
+	return r.logging
+}
+
+// Authorizes request against route Access and Policy. Responds 401 when claims are
+// missing and 403 when claims are not authorized
+func (r *Router) authorize(route Route, i *Input) domain.Response {
+	var policies []domain.Policy
+	if route.Access != nil {
+		policies = append(policies, route.Access)
+	}
+	if route.Policy != nil {
+		policies = append(policies, route.Policy)
+	}
+	if len(policies) == 0 {
+		return nil
 	}
 
 	claims, err := i.Auth()
 	if err != nil {
-		return false
+		return Unauthorized("Missing or invalid credentials")
 	}
 
-	reqRole := claims.Get(access.Key)
-	if reqRole == nil {
-		return false
+	req := &domain.PolicyRequest{Claims: claims, PathParams: i.pathParams()}
+	if !domain.All(policies...).Authorize(req) {
+		return Forbidden("Access denied")
 	}
+	return nil
+}
 
-	match := false
-	for _, r := range access.Roles {
-		if reqRole == r {
-			match = true
+// Checks body size and media type against router limits and route Consumes
+func (r *Router) checkBody(route Route, i *Input) domain.Response {
+	body, err := i.Body()
+	if err == ErrBodyTooLarge {
+		return NewError(http.StatusRequestEntityTooLarge, "Request body too large")
+	}
+	if len(body) == 0 || len(route.Consumes) == 0 {
+		return nil
+	}
+
+	mediaType := i.ContentType()
+	for _, consumes := range route.Consumes {
+		if strings.EqualFold(consumes, mediaType) {
+			return nil
+		}
+	}
+	return NewError(http.StatusUnsupportedMediaType, "Unsupported media type")
+}
+
+// Encodes response body with the encoder negotiated from the Accept header. Responds 406
+// when none of the produced media types is acceptable and 500 when encoding fails
+func (r *Router) encode(route Route, i *Input, res *Response) *Response {
+	if res.encoded {
+		return res
+	}
+
+	mediaType := res.contentType
+	if mediaType == "" {
+		if mediaType = i.Accepts(produces(route)...); mediaType == "" {
+			return r.errorResponse(i, NewError(http.StatusNotAcceptable, "None of the available media types is acceptable"))
 		}
 	}
-	return match
+
+	encoder := r.encoder(mediaType)
+	if encoder == nil {
+		return r.errorResponse(i, Internal(fmt.Errorf("encoder missing for media type %s", mediaType)))
+	}
+	if err := res.encode(mediaType, encoder); err != nil {
+		return r.errorResponse(i, Internal(fmt.Errorf("could not encode response as %s: %w", mediaType, err)))
+	}
+	return res
+}
+
+// Media types offered for route's success bodies, JSON unless listed in Produces
+func produces(route Route) []string {
+	var offers []string
+	for _, mediaType := range route.Produces {
+		if mediaType != MediaTypeProblemJSON {
+			offers = append(offers, mediaType)
+		}
+	}
+	if len(offers) == 0 {
+		return []string{MediaTypeJSON}
+	}
+	return offers
+}
+
+// Encoder registered for media type, JSON media types fall back to encoding/json
+func (r *Router) encoder(mediaType string) Encoder {
+	if encoder, ok := r.encoders[mediaType]; ok {
+		return encoder
+	}
+	if isJSON(mediaType) {
+		return EncoderFunc(json.Marshal)
+	}
+	return nil
+}
+
+// Static problem details for when error responses can not be formatted or encoded
+func (r *Router) internalError() *Response {
+	res := ProblemFormatter(nil, Internal(nil))
+	_ = res.encode(MediaTypeProblemJSON, EncoderFunc(json.Marshal))
+	return res
 }
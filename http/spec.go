@@ -0,0 +1,69 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+)
+
+// RequestValidator checks requests against an API specification before binding, e.g.
+// openapi.Validator. Returned *Error values are responded as is, other errors respond 400
+type RequestValidator interface {
+	ValidateRequest(i *Input, method string, resource string) error
+}
+
+// ResponseValidator checks encoded responses against an API specification
+type ResponseValidator interface {
+	ValidateResponse(i *Input, method string, resource string, status int, contentType string, body []byte) error
+}
+
+// WithRequestValidator for checking requests before binding and validating Route.Request
+func WithRequestValidator(validator RequestValidator) Option {
+	return func(r *Router) {
+		r.requestValidator = validator
+	}
+}
+
+// WithResponseValidator for checking handler responses, intended for tests. Violations
+// are logged and respond 500
+func WithResponseValidator(validator ResponseValidator) Option {
+	return func(r *Router) {
+		r.responseValidator = validator
+	}
+}
+
+func (r *Router) validateRequest(evt map[string]interface{}, i *Input) domain.Response {
+	if r.requestValidator == nil {
+		return nil
+	}
+	err := r.requestValidator.ValidateRequest(i, evt["httpMethod"].(string), r.resource(evt))
+	if err == nil {
+		return nil
+	}
+
+	i.logger.Info("Router::Route() request does not match specification", domain.Fields{"error": err.Error()})
+	var typed *Error
+	if errors.As(err, &typed) {
+		return typed
+	}
+	return BadRequest(err.Error())
+}
+
+func (r *Router) validateResponse(evt map[string]interface{}, i *Input, res *Response) *Response {
+	if r.responseValidator == nil || res.isBase64Encoded {
+		return res
+	}
+	err := r.responseValidator.ValidateResponse(
+		i,
+		evt["httpMethod"].(string),
+		r.resource(evt),
+		res.statusCode,
+		res.headers["Content-Type"],
+		[]byte(res.body),
+	)
+	if err == nil {
+		return res
+	}
+	return r.errorResponse(i, Internal(fmt.Errorf("response does not match specification: %w", err)))
+}
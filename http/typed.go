@@ -0,0 +1,38 @@
+package http
+
+import (
+	"context"
+	"reflect"
+)
+
+type inputKey struct{}
+
+// InputFromContext returns the Input of the current request within typed handlers
+func InputFromContext(ctx context.Context) (*Input, bool) {
+	i, ok := ctx.Value(inputKey{}).(*Input)
+	return i, ok
+}
+
+// JSON Route for a typed handler. Struct requests are bound and validated by the router
+// like Route.Request, other request types are decoded from the JSON body with failures
+// responded as 400. The response is encoded with status on success and errors are
+// mapped like Handle
+func JSON[Req any, Resp any](status int, fn func(ctx context.Context, req Req) (Resp, error)) Route {
+	var req Req
+	var resp Resp
+	route := Route{Response: resp, Status: status}
+	if reflect.TypeOf(req) != nil && reflect.TypeOf(req).Kind() == reflect.Struct {
+		route.Request = req
+	}
+
+	route.Handler = Handle(status, func(i *Input) (interface{}, error) {
+		var req Req
+		if bound, ok := i.Request().(*Req); ok {
+			req = *bound
+		} else if err := i.ParseBody(&req); err != nil {
+			return nil, BadRequest(err.Error())
+		}
+		return fn(context.WithValue(i.Context(), inputKey{}, i), req)
+	})
+	return route
+}
@@ -0,0 +1,168 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/validate"
+)
+
+var (
+	methods   = []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions, "ANY"}
+	paramName = regexp.MustCompile(`^[A-Za-z0-9_.-]+\+?$`)
+)
+
+// Routes registered in router sorted by template and method
+func (r *Router) Routes() []domain.RouteInfo {
+	var routes []domain.RouteInfo
+	for _, path := range r.paths() {
+		for _, method := range sortedMethods(r.routes[path]) {
+			route := r.routes[path][method]
+			routes = append(routes, domain.RouteInfo{
+				Source:   domain.SourceHTTP,
+				Key:      path,
+				Method:   method,
+				Access:   route.Access,
+				Policy:   route.Policy,
+				Payload:  route.Request,
+				Response: route.Response,
+			})
+		}
+	}
+	return routes
+}
+
+// Validate route table, reporting invalid templates and methods, missing handlers, nil
+// middleware, non struct Request types, Request fields that can not be bound, invalid
+// validate tags on Request and Produces without a registered encoder
+func (r *Router) Validate() error {
+	var errs []error
+	for n, m := range r.middleware {
+		if m == nil {
+			errs = append(errs, fmt.Errorf("middleware %d is nil", n))
+		}
+	}
+
+	for _, path := range r.paths() {
+		if err := validateTemplate(path); err != nil {
+			errs = append(errs, fmt.Errorf("route %s: %w", path, err))
+		}
+		for _, method := range sortedMethods(r.routes[path]) {
+			for _, err := range r.validateRoute(method, r.routes[path][method]) {
+				errs = append(errs, fmt.Errorf("route %s %s: %w", method, path, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Router) validateRoute(method string, route Route) []error {
+	var errs []error
+	if !contains(methods, method) {
+		errs = append(errs, fmt.Errorf("invalid method %q", method))
+	}
+	if route.Handler == nil {
+		errs = append(errs, errors.New("handler missing"))
+	}
+	for n, m := range route.Middleware {
+		if m == nil {
+			errs = append(errs, fmt.Errorf("middleware %d is nil", n))
+		}
+	}
+	if route.Request != nil && reflect.TypeOf(route.Request).Kind() != reflect.Struct {
+		errs = append(errs, fmt.Errorf("request must be a struct, got %T", route.Request))
+	} else if route.Request != nil {
+		errs = append(errs, bindingErrors(reflect.TypeOf(route.Request))...)
+		errs = append(errs, tagErrors(validate.Check(route.Request))...)
+	}
+	for _, mediaType := range route.Produces {
+		if mediaType == MediaTypeProblemJSON {
+			errs = append(errs, fmt.Errorf("produced media type %s is reserved for error responses", mediaType))
+			continue
+		}
+		if r.encoder(mediaType) == nil {
+			errs = append(errs, fmt.Errorf("encoder missing for produced media type %s", mediaType))
+		}
+	}
+	return errs
+}
+
+// Invalid validate tags of a Request type, one error per field
+func tagErrors(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var errs []error
+		for _, err := range joined.Unwrap() {
+			errs = append(errs, tagErrors(err)...)
+		}
+		return errs
+	}
+	if err != nil {
+		return []error{err}
+	}
+	return nil
+}
+
+// Validates template starts with a slash and has balanced, named and unique path params,
+// greedy params only being allowed as the last segment
+func validateTemplate(path string) error {
+	if !strings.HasPrefix(path, "/") {
+		return errors.New("template must start with /")
+	}
+
+	seen := map[string]bool{}
+	segments := strings.Split(path, "/")
+	for n, segment := range segments {
+		opening, closing := strings.Count(segment, "{"), strings.Count(segment, "}")
+		if opening == 0 && closing == 0 {
+			continue
+		}
+		if opening != 1 || closing != 1 || !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+			return fmt.Errorf("unbalanced braces in segment %q", segment)
+		}
+		name := strings.TrimSuffix(segment[1:len(segment)-1], "+")
+		if !paramName.MatchString(segment[1 : len(segment)-1]) {
+			return fmt.Errorf("invalid path param %q", segment)
+		}
+		if seen[name] {
+			return fmt.Errorf("duplicate path param %q", name)
+		}
+		if strings.HasSuffix(segment, "+}") && n != len(segments)-1 {
+			return fmt.Errorf("greedy path param %q must be the last segment", segment)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+func (r *Router) paths() []string {
+	paths := make([]string, 0, len(r.routes))
+	for path := range r.routes {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func sortedMethods(routes map[string]Route) []string {
+	methods := make([]string, 0, len(routes))
+	for method := range routes {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,165 @@
+package http
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// GetQueryParams all values of query param. Reads multiValueQueryStringParameters, or the
+// rawQueryString of HTTP API payload version 2.0 so that values containing commas are kept
+// intact. Without rawQueryString comma joined version 2.0 values are split, which can not
+// tell repeated keys from a single value containing commas
+func (i *Input) GetQueryParams(param string) []string {
+	if values := i.multiValue("multiValueQueryStringParameters", param, false); values != nil {
+		return values
+	}
+	if values := i.rawQueryValues(param); values != nil {
+		return values
+	}
+	params, _ := i.event["queryStringParameters"].(map[string]interface{})
+	if _, ok := params[param]; !ok {
+		return []string{}
+	}
+	return i.splitJoined(i.GetQueryParam(param))
+}
+
+// GetHeaderValues all values of header, matched case-insensitively. Comma joined values of
+// HTTP API payload version 2.0 are only split for list headers such as Accept
+func (i *Input) GetHeaderValues(header string) []string {
+	if values := i.multiValue("multiValueHeaders", header, true); values != nil {
+		return values
+	}
+	value := i.GetHeader(header)
+	if value == "" {
+		return []string{}
+	}
+	if i.event["version"] != "2.0" || !listHeaders[http.CanonicalHeaderKey(header)] {
+		return []string{value}
+	}
+	return splitList(value)
+}
+
+// Headers defined as comma separated lists, HTTP API payload version 2.0 joins repeated
+// values of other headers too but splitting them would break values such as dates
+var listHeaders = map[string]bool{
+	"Accept":                         true,
+	"Accept-Charset":                 true,
+	"Accept-Encoding":                true,
+	"Accept-Language":                true,
+	"Access-Control-Request-Headers": true,
+	"Allow":                          true,
+	"Cache-Control":                  true,
+	"Connection":                     true,
+	"Content-Encoding":               true,
+	"Forwarded":                      true,
+	"If-Match":                       true,
+	"If-None-Match":                  true,
+	"Pragma":                         true,
+	"Te":                             true,
+	"Trailer":                        true,
+	"Transfer-Encoding":              true,
+	"Upgrade":                        true,
+	"Vary":                           true,
+	"Via":                            true,
+	"X-Forwarded-For":                true,
+}
+
+// Splits comma separated list, ignoring commas within quoted strings
+func splitList(value string) []string {
+	var parts []string
+	quoted := false
+	start := 0
+	for n, r := range value {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ',' && !quoted:
+			parts = append(parts, strings.TrimSpace(value[start:n]))
+			start = n + 1
+		}
+	}
+	return append(parts, strings.TrimSpace(value[start:]))
+}
+
+// QueryValues view of all query params
+func (i *Input) QueryValues() url.Values {
+	out := url.Values{}
+	for _, key := range i.keys(false, "multiValueQueryStringParameters", "queryStringParameters") {
+		out[key] = i.GetQueryParams(key)
+	}
+	return out
+}
+
+// Header view of all headers with canonical keys
+func (i *Input) Header() http.Header {
+	out := http.Header{}
+	for _, key := range i.keys(true, "multiValueHeaders", "headers") {
+		for _, v := range i.GetHeaderValues(key) {
+			out.Add(key, v)
+		}
+	}
+	return out
+}
+
+// Values for key in a multi value map such as multiValueHeaders, nil when absent
+func (i *Input) multiValue(field string, key string, caseInsensitive bool) []string {
+	values, _ := i.event[field].(map[string]interface{})
+	for k, v := range values {
+		if k != key && !(caseInsensitive && strings.EqualFold(k, key)) {
+			continue
+		}
+		list, _ := v.([]interface{})
+		out := make([]string, 0, len(list))
+		for _, value := range list {
+			out = append(out, stringValue(value))
+		}
+		return out
+	}
+	return nil
+}
+
+// Values for param parsed from rawQueryString of HTTP API payload version 2.0, nil when absent
+func (i *Input) rawQueryValues(param string) []string {
+	raw, ok := i.event["rawQueryString"].(string)
+	if !ok || i.event["version"] != "2.0" {
+		return nil
+	}
+	query, err := url.ParseQuery(raw)
+	if err != nil {
+		return nil
+	}
+	return query[param]
+}
+
+// HTTP API payload version 2.0 joins repeated values with commas
+func (i *Input) splitJoined(value string) []string {
+	if i.event["version"] != "2.0" {
+		return []string{value}
+	}
+	parts := strings.Split(value, ",")
+	for n, p := range parts {
+		parts[n] = strings.TrimSpace(p)
+	}
+	return parts
+}
+
+// Distinct keys of the given event maps
+func (i *Input) keys(caseInsensitive bool, fields ...string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, field := range fields {
+		values, _ := i.event[field].(map[string]interface{})
+		for k := range values {
+			key := k
+			if caseInsensitive {
+				key = strings.ToLower(k)
+			}
+			if !seen[key] {
+				seen[key] = true
+				out = append(out, k)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,163 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// JWK single JSON web key
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg,omitempty"`
+	Use string `json:"use,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKS JSON web key set document
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// Loads JWKS document from file or URL and caches parsed keys
+type keySet struct {
+	file     string
+	url      string
+	client   *http.Client
+	ttl      time.Duration
+	now      func() time.Time
+	keys     map[string]crypto.PublicKey
+	loadedAt time.Time
+	failedAt time.Time
+	err      error
+	mu       sync.Mutex
+}
+
+// Minimum time between reloads triggered by unknown key ids or after failed loads
+const minRefreshInterval = time.Minute
+
+func (ks *keySet) key(kid string) (crypto.PublicKey, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	age := ks.now().Sub(ks.loadedAt)
+	_, known := ks.keys[kid]
+	stale := age > ks.ttl || (!known && age > minRefreshInterval)
+	if (ks.keys == nil || stale) && ks.now().Sub(ks.failedAt) > minRefreshInterval {
+		if err := ks.load(); err != nil {
+			// Serve cached keys, or the load error without keys, until a reload succeeds,
+			// retrying at most once a minute
+			ks.failedAt = ks.now()
+			ks.err = err
+		}
+	}
+	if ks.keys == nil {
+		return nil, ks.err
+	}
+
+	key, ok := ks.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	return key, nil
+}
+
+func (ks *keySet) load() error {
+	var raw []byte
+	var err error
+	if ks.file != "" {
+		raw, err = os.ReadFile(ks.file)
+	} else {
+		raw, err = ks.fetch()
+	}
+	if err != nil {
+		return err
+	}
+
+	var doc JWKS
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return errors.New("could not parse JWKS document")
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(doc.Keys))
+	for _, k := range doc.Keys {
+		key, err := k.PublicKey()
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = key
+	}
+	ks.keys = keys
+	ks.loadedAt = ks.now()
+	return nil
+}
+
+func (ks *keySet) fetch() ([]byte, error) {
+	if ks.url == "" {
+		return nil, errors.New("missing JWKS file or URL")
+	}
+	res, err := ks.client.Get(ks.url)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch JWKS: %v", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not fetch JWKS: status %d", res.StatusCode)
+	}
+	return io.ReadAll(res.Body)
+}
+
+// PublicKey parsed from RSA or EC P-256 key parameters
+func (k JWK) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.New("invalid key parameter encoding")
+	}
+	return new(big.Int).SetBytes(decoded), nil
+}
@@ -0,0 +1,187 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Errors returned by Verifier
+var (
+	ErrMalformed        = errors.New("malformed token")
+	ErrUnsupportedAlg   = errors.New("unsupported signing algorithm")
+	ErrInvalidSignature = errors.New("invalid token signature")
+	ErrMissingExpiry    = errors.New("token missing exp claim")
+	ErrExpired          = errors.New("token expired")
+	ErrNotYetValid      = errors.New("token not yet valid")
+	ErrInvalidIssuer    = errors.New("invalid token issuer")
+	ErrInvalidAudience  = errors.New("invalid token audience")
+)
+
+// Config for Verifier
+type Config struct {
+	// Issuer expected in iss claim, not checked when empty
+	Issuer string
+	// Audience accepted in aud claim, not checked when empty
+	Audience []string
+	// JWKSFile path to JWKS document, takes precedence over JWKSURL
+	JWKSFile string
+	// JWKSURL to fetch JWKS document from
+	JWKSURL string
+	// CacheTTL for loaded keys, defaults to one hour
+	CacheTTL time.Duration
+	// Leeway for exp and nbf checks
+	Leeway time.Duration
+	// HTTPClient for fetching JWKS, defaults to a client with 5 second timeout
+	HTTPClient *http.Client
+	// Now overrides current time, used in tests
+	Now func() time.Time
+}
+
+// Verifier for RS256 and ES256 signed JWTs
+type Verifier struct {
+	config Config
+	keys   *keySet
+}
+
+// NewVerifier initializer
+func NewVerifier(config Config) *Verifier {
+	if config.CacheTTL == 0 {
+		config.CacheTTL = time.Hour
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: 5 * time.Second}
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &Verifier{
+		config: config,
+		keys: &keySet{
+			file:   config.JWKSFile,
+			url:    config.JWKSURL,
+			client: config.HTTPClient,
+			ttl:    config.CacheTTL,
+			now:    config.Now,
+		},
+	}
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// Verify token signature and registered claims, returns verified claims
+func (v *Verifier) Verify(token string) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformed
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, ErrMalformed
+	}
+	var claims map[string]interface{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, ErrMalformed
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+
+	key, err := v.keys.key(h.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySignature(h.Alg, key, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+	if err := v.verifyClaims(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signed string, signature []byte) error {
+	hash := sha256.Sum256([]byte(signed))
+	switch alg {
+	case "RS256":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok || rsa.VerifyPKCS1v15(pub, crypto.SHA256, hash[:], signature) != nil {
+			return ErrInvalidSignature
+		}
+		return nil
+	case "ES256":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok || len(signature) != 64 {
+			return ErrInvalidSignature
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(pub, hash[:], r, s) {
+			return ErrInvalidSignature
+		}
+		return nil
+	default:
+		return ErrUnsupportedAlg
+	}
+}
+
+func (v *Verifier) verifyClaims(claims map[string]interface{}) error {
+	now := v.config.Now()
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return ErrMissingExpiry
+	}
+	if now.After(time.Unix(int64(exp), 0).Add(v.config.Leeway)) {
+		return ErrExpired
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Before(time.Unix(int64(nbf), 0).Add(-v.config.Leeway)) {
+		return ErrNotYetValid
+	}
+	if v.config.Issuer != "" && claims["iss"] != v.config.Issuer {
+		return ErrInvalidIssuer
+	}
+	if len(v.config.Audience) > 0 && !v.hasAudience(claims["aud"]) {
+		return ErrInvalidAudience
+	}
+	return nil
+}
+
+func (v *Verifier) hasAudience(aud interface{}) bool {
+	var values []interface{}
+	switch a := aud.(type) {
+	case string:
+		values = []interface{}{a}
+	case []interface{}:
+		values = a
+	}
+
+	for _, value := range values {
+		for _, expected := range v.config.Audience {
+			if value == expected {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func decodeSegment(segment string, out interface{}) error {
+	decoded, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(decoded, out)
+}
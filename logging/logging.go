@@ -0,0 +1,52 @@
+package logging
+
+import (
+	"os"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/logger"
+)
+
+// Default Logger backed by github.com/matthisstenius/logger
+func Default() domain.Logger {
+	return &defaultLogger{fields: domain.Fields{}}
+}
+
+type defaultLogger struct {
+	fields domain.Fields
+}
+
+// Debug is emitted on info level when LOG_LEVEL is DEBUG since logger lacks a debug level
+func (l *defaultLogger) Debug(message string, fields domain.Fields) {
+	if os.Getenv("LOG_LEVEL") != "DEBUG" {
+		return
+	}
+	logger.WithFields(l.merge(fields)).Info(message)
+}
+
+func (l *defaultLogger) Info(message string, fields domain.Fields) {
+	logger.WithFields(l.merge(fields)).Info(message)
+}
+
+func (l *defaultLogger) Error(message string, fields domain.Fields) {
+	logger.WithFields(l.merge(fields)).Error(message)
+}
+
+func (l *defaultLogger) With(fields domain.Fields) domain.Logger {
+	return &defaultLogger{fields: merge(l.fields, fields)}
+}
+
+func (l *defaultLogger) merge(fields domain.Fields) logger.Fields {
+	return logger.Fields(merge(l.fields, fields))
+}
+
+func merge(base domain.Fields, fields domain.Fields) domain.Fields {
+	out := make(domain.Fields, len(base)+len(fields))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range fields {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,20 @@
+package logging
+
+import "github.com/matthisstenius/lambda-router/v4/domain"
+
+// Nop Logger discarding all log lines
+func Nop() domain.Logger {
+	return nop{}
+}
+
+type nop struct{}
+
+func (nop) Debug(message string, fields domain.Fields) {}
+
+func (nop) Info(message string, fields domain.Fields) {}
+
+func (nop) Error(message string, fields domain.Fields) {}
+
+func (n nop) With(fields domain.Fields) domain.Logger {
+	return n
+}
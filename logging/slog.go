@@ -0,0 +1,53 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+)
+
+// NewSlog Logger adapter for log/slog
+func NewSlog(l *slog.Logger) domain.Logger {
+	return &slogLogger{logger: l}
+}
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+func (l *slogLogger) Debug(message string, fields domain.Fields) {
+	l.logger.LogAttrs(context.Background(), slog.LevelDebug, message, attrs(fields)...)
+}
+
+func (l *slogLogger) Info(message string, fields domain.Fields) {
+	l.logger.LogAttrs(context.Background(), slog.LevelInfo, message, attrs(fields)...)
+}
+
+func (l *slogLogger) Error(message string, fields domain.Fields) {
+	l.logger.LogAttrs(context.Background(), slog.LevelError, message, attrs(fields)...)
+}
+
+func (l *slogLogger) With(fields domain.Fields) domain.Logger {
+	args := make([]interface{}, 0, len(fields))
+	for _, a := range attrs(fields) {
+		args = append(args, a)
+	}
+	return &slogLogger{logger: l.logger.With(args...)}
+}
+
+// Converts fields into attributes sorted by key for stable output
+func attrs(fields domain.Fields) []slog.Attr {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := make([]slog.Attr, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, slog.Any(k, fields[k]))
+	}
+	return out
+}
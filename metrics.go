@@ -0,0 +1,73 @@
+package router
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/metrics"
+)
+
+// Metric names emitted for every routed event
+const (
+	MetricInvocations = "Invocations"
+	MetricLatency     = "Latency"
+	MetricErrors      = "Errors"
+)
+
+// Dimension names shared by every emitted metric
+const (
+	DimensionSource = "Source"
+	DimensionRoute  = "Route"
+)
+
+// MetricsConfig for routing outcome metrics
+type MetricsConfig struct {
+	// Namespace of emitted metrics, defaults to LambdaRouter
+	Namespace string
+	// Writer receiving EMF documents, defaults to stdout
+	Writer io.Writer
+}
+
+func (c *MetricsConfig) newMetrics(source string, routeKey string) *metrics.Metrics {
+	namespace := c.Namespace
+	if namespace == "" {
+		namespace = "LambdaRouter"
+	}
+	if routeKey == "" {
+		routeKey = "unknown"
+	}
+
+	m := metrics.New(namespace)
+	m.SetDimension(DimensionSource, source)
+	m.SetDimension(DimensionRoute, routeKey)
+	return m
+}
+
+// Records routing outcome on metrics document and writes it
+func (c *MetricsConfig) flush(log domain.Logger, m *metrics.Metrics, latency time.Duration, payload interface{}, err error) {
+	failed := err != nil
+	if status, ok := statusCode(payload); ok {
+		m.Put(fmt.Sprintf("Status%dxx", status/100), 1, metrics.UnitCount)
+		m.SetProperty("statusCode", status)
+		failed = failed || status >= 500
+	}
+
+	failures := 0.0
+	if failed {
+		failures = 1
+	}
+	m.Put(MetricInvocations, 1, metrics.UnitCount)
+	m.Put(MetricErrors, failures, metrics.UnitCount)
+	m.Put(MetricLatency, float64(latency)/float64(time.Millisecond), metrics.UnitMilliseconds)
+
+	w := c.Writer
+	if w == nil {
+		w = os.Stdout
+	}
+	if err := m.Flush(w); err != nil {
+		log.Error("Could not write metrics", domain.Fields{"error": err})
+	}
+}
@@ -0,0 +1,153 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Unit of a metric value
+type Unit string
+
+// Units supported by CloudWatch
+const (
+	UnitNone         Unit = "None"
+	UnitCount        Unit = "Count"
+	UnitMilliseconds Unit = "Milliseconds"
+	UnitSeconds      Unit = "Seconds"
+	UnitBytes        Unit = "Bytes"
+	UnitPercent      Unit = "Percent"
+)
+
+// Metrics document emitted in CloudWatch Embedded Metric Format
+type Metrics struct {
+	namespace  string
+	timestamp  time.Time
+	dimensions map[string]string
+	metrics    map[string]metric
+	properties map[string]interface{}
+	mu         sync.Mutex
+}
+
+type metric struct {
+	unit   Unit
+	values []float64
+}
+
+// New Metrics document for namespace
+func New(namespace string) *Metrics {
+	return &Metrics{
+		namespace:  namespace,
+		timestamp:  time.Now(),
+		dimensions: map[string]string{},
+		metrics:    map[string]metric{},
+		properties: map[string]interface{}{},
+	}
+}
+
+// SetDimension shared by every metric in the document
+func (m *Metrics) SetDimension(key string, value string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.dimensions[key] = value
+}
+
+// Put metric value, repeated values for the same name are emitted as an array
+func (m *Metrics) Put(name string, value float64, unit Unit) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry := m.metrics[name]
+	entry.unit = unit
+	entry.values = append(entry.values, value)
+	m.metrics[name] = entry
+}
+
+// SetProperty added to the document without being a metric or dimension
+func (m *Metrics) SetProperty(key string, value interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.properties[key] = value
+}
+
+// SetTimestamp of the document, defaults to creation time
+func (m *Metrics) SetTimestamp(t time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.timestamp = t
+}
+
+// MarshalJSON document in Embedded Metric Format
+func (m *Metrics) MarshalJSON() ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	doc := make(map[string]interface{}, len(m.properties)+len(m.dimensions)+len(m.metrics)+1)
+	for k, v := range m.properties {
+		doc[k] = v
+	}
+
+	dimensions := make([]string, 0, len(m.dimensions))
+	for k, v := range m.dimensions {
+		dimensions = append(dimensions, k)
+		doc[k] = v
+	}
+	sort.Strings(dimensions)
+
+	names := make([]string, 0, len(m.metrics))
+	for name := range m.metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	definitions := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		entry := m.metrics[name]
+		definitions = append(definitions, map[string]interface{}{"Name": name, "Unit": entry.unit})
+		if len(entry.values) == 1 {
+			doc[name] = entry.values[0]
+		} else {
+			doc[name] = entry.values
+		}
+	}
+
+	doc["_aws"] = map[string]interface{}{
+		"Timestamp": m.timestamp.UnixNano() / int64(time.Millisecond),
+		"CloudWatchMetrics": []map[string]interface{}{
+			{
+				"Namespace":  m.namespace,
+				"Dimensions": [][]string{dimensions},
+				"Metrics":    definitions,
+			},
+		},
+	}
+	return json.Marshal(doc)
+}
+
+// Flush document to writer as a single line
+func (m *Metrics) Flush(w io.Writer) error {
+	encoded, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(encoded, '\n'))
+	return err
+}
+
+type contextKey struct{}
+
+// NewContext carrying Metrics document
+func NewContext(ctx context.Context, m *Metrics) context.Context {
+	return context.WithValue(ctx, contextKey{}, m)
+}
+
+// FromContext Metrics document for current invocation. Returns a detached document
+// when metrics are disabled so handlers never need to nil check
+func FromContext(ctx context.Context) *Metrics {
+	if m, ok := ctx.Value(contextKey{}).(*Metrics); ok {
+		return m
+	}
+	return New("")
+}
@@ -0,0 +1,59 @@
+package mock
+
+import "github.com/matthisstenius/lambda-router/v4/domain"
+
+// LogEntry recorded by Logger
+type LogEntry struct {
+	Level   string
+	Message string
+	Fields  domain.Fields
+}
+
+// Logger mock recording every log line
+type Logger struct {
+	Entries *[]LogEntry
+	fields  domain.Fields
+}
+
+// NewLogger initializer
+func NewLogger() *Logger {
+	return &Logger{Entries: &[]LogEntry{}, fields: domain.Fields{}}
+}
+
+// Debug mock implementation
+func (l *Logger) Debug(message string, fields domain.Fields) {
+	l.record("debug", message, fields)
+}
+
+// Info mock implementation
+func (l *Logger) Info(message string, fields domain.Fields) {
+	l.record("info", message, fields)
+}
+
+// Error mock implementation
+func (l *Logger) Error(message string, fields domain.Fields) {
+	l.record("error", message, fields)
+}
+
+// With mock implementation sharing recorded entries
+func (l *Logger) With(fields domain.Fields) domain.Logger {
+	merged := domain.Fields{}
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return &Logger{Entries: l.Entries, fields: merged}
+}
+
+func (l *Logger) record(level string, message string, fields domain.Fields) {
+	merged := domain.Fields{}
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	*l.Entries = append(*l.Entries, LogEntry{Level: level, Message: message, Fields: merged})
+}
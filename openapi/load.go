@@ -0,0 +1,189 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+var methods = map[string]bool{
+	"get": true, "put": true, "post": true, "delete": true,
+	"options": true, "head": true, "patch": true, "trace": true,
+}
+
+// Load JSON or YAML encoded document. Only the subset of OpenAPI produced by Generate is
+// decoded, other keywords are ignored. Path level parameters are merged into operations
+// and $ref parameters, request bodies and responses are resolved from components
+func Load(data []byte) (*Document, error) {
+	if !json.Valid(data) {
+		var v interface{}
+		if err := yaml.Unmarshal(data, &v); err != nil {
+			return nil, fmt.Errorf("could not parse document: %w", err)
+		}
+		converted, err := json.Marshal(jsonValue(v))
+		if err != nil {
+			return nil, fmt.Errorf("could not parse document: %w", err)
+		}
+		data = converted
+	}
+
+	doc := &Document{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, fmt.Errorf("could not parse document: %w", err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q", doc.OpenAPI)
+	}
+	if err := doc.resolve(); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// LoadFile JSON or YAML encoded document from path
+func LoadFile(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Load(data)
+}
+
+// UnmarshalJSON operations of path item. Path level parameters are appended to the
+// parameters of every operation, other path level fields are ignored
+func (p *PathItem) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["$ref"]; ok {
+		return errors.New("path item $ref is not supported")
+	}
+
+	var params []*Parameter
+	if value, ok := raw["parameters"]; ok {
+		if err := json.Unmarshal(value, &params); err != nil {
+			return fmt.Errorf("parameters: %w", err)
+		}
+	}
+
+	*p = PathItem{}
+	for method, value := range raw {
+		if !methods[method] {
+			continue
+		}
+		op := &Operation{}
+		if err := json.Unmarshal(value, op); err != nil {
+			return fmt.Errorf("%s: %w", method, err)
+		}
+		op.Parameters = append(op.Parameters, params...)
+		(*p)[method] = op
+	}
+	return nil
+}
+
+// Resolves $ref parameters, request bodies and responses of operations from components
+// and drops path level parameters overridden by the operation
+func (d *Document) resolve() error {
+	paths := make([]string, 0, len(d.Paths))
+	for path := range d.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var errs []error
+	for _, path := range paths {
+		for method, op := range d.Paths[path] {
+			if err := d.resolveOperation(op); err != nil {
+				errs = append(errs, fmt.Errorf("%s %s: %w", strings.ToUpper(method), path, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (d *Document) resolveOperation(op *Operation) error {
+	var params []*Parameter
+	seen := map[string]bool{}
+	for _, param := range op.Parameters {
+		resolved, err := resolveRef(param, "parameters", d.Components.Parameters)
+		if err != nil {
+			return err
+		}
+		// Operation parameters precede path level parameters with the same name and location
+		if key := resolved.In + " " + resolved.Name; !seen[key] {
+			seen[key] = true
+			params = append(params, resolved)
+		}
+	}
+	op.Parameters = params
+
+	if op.RequestBody != nil {
+		resolved, err := resolveRef(op.RequestBody, "requestBodies", d.Components.RequestBodies)
+		if err != nil {
+			return err
+		}
+		op.RequestBody = resolved
+	}
+	for status, res := range op.Responses {
+		resolved, err := resolveRef(res, "responses", d.Components.Responses)
+		if err != nil {
+			return err
+		}
+		op.Responses[status] = resolved
+	}
+	return nil
+}
+
+func (p *Parameter) reference() string   { return p.Ref }
+func (b *RequestBody) reference() string { return b.Ref }
+func (r *Response) reference() string    { return r.Ref }
+
+// Follows $ref of value to components of kind, e.g. #/components/parameters/id
+func resolveRef[T any, P interface {
+	*T
+	reference() string
+}](value P, kind string, components map[string]P) (P, error) {
+	prefix := "#/components/" + kind + "/"
+	for n := 0; value != nil && value.reference() != ""; n++ {
+		ref := value.reference()
+		if n > len(components) {
+			return value, fmt.Errorf("circular reference %s", ref)
+		}
+		if !strings.HasPrefix(ref, prefix) {
+			return value, fmt.Errorf("unsupported reference %s", ref)
+		}
+		next, ok := components[strings.TrimPrefix(ref, prefix)]
+		if !ok {
+			return value, fmt.Errorf("unresolved reference %s", ref)
+		}
+		value = next
+	}
+	if value == nil {
+		return nil, fmt.Errorf("null value in %s", kind)
+	}
+	return value, nil
+}
+
+// Maps YAML decoded values to values encodable as JSON
+func jsonValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			out[fmt.Sprint(k)] = jsonValue(item)
+		}
+		return out
+	case []interface{}:
+		for n, item := range val {
+			val[n] = jsonValue(item)
+		}
+		return val
+	}
+	return v
+}
@@ -0,0 +1,405 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	lambdaHTTP "github.com/matthisstenius/lambda-router/v4/http"
+	"github.com/matthisstenius/lambda-router/v4/schema"
+	"gopkg.in/yaml.v2"
+)
+
+// Version of generated documents
+const Version = "3.1.0"
+
+// ProblemSchema name of the problem details schema referenced by error responses
+const ProblemSchema = "Problem"
+
+const refPrefix = "#/components/schemas/"
+
+var pathParam = regexp.MustCompile(`\{([^}+]+)\+?\}`)
+
+// Document OpenAPI 3.1 document
+type Document struct {
+	OpenAPI    string              `json:"openapi" yaml:"openapi"`
+	Info       Info                `json:"info" yaml:"info"`
+	Servers    []Server            `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Paths      map[string]PathItem `json:"paths" yaml:"paths"`
+	Components Components          `json:"components" yaml:"components"`
+}
+
+// Info about the API
+type Info struct {
+	Title       string `json:"title" yaml:"title"`
+	Version     string `json:"version" yaml:"version"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// Server the API is served from
+type Server struct {
+	URL         string `json:"url" yaml:"url"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// PathItem operations keyed by lowercase method
+type PathItem map[string]*Operation
+
+// Operation on a path
+type Operation struct {
+	OperationID string                `json:"operationId" yaml:"operationId"`
+	Summary     string                `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description string                `json:"description,omitempty" yaml:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses" yaml:"responses"`
+	Security    []map[string][]string `json:"security,omitempty" yaml:"security,omitempty"`
+}
+
+// Parameter in path, query or header
+type Parameter struct {
+	Ref      string         `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Name     string         `json:"name" yaml:"name"`
+	In       string         `json:"in" yaml:"in"`
+	Required bool           `json:"required,omitempty" yaml:"required,omitempty"`
+	Schema   *schema.Schema `json:"schema" yaml:"schema"`
+}
+
+// RequestBody of an operation
+type RequestBody struct {
+	Ref      string                `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Required bool                  `json:"required,omitempty" yaml:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content" yaml:"content"`
+}
+
+// Response of an operation
+type Response struct {
+	Ref         string                `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Description string                `json:"description" yaml:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+// MediaType content schema
+type MediaType struct {
+	Schema *schema.Schema `json:"schema" yaml:"schema"`
+}
+
+// Components shared by operations
+type Components struct {
+	Schemas         map[string]*schema.Schema  `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty" yaml:"securitySchemes,omitempty"`
+	Parameters      map[string]*Parameter      `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBodies   map[string]*RequestBody    `json:"requestBodies,omitempty" yaml:"requestBodies,omitempty"`
+	Responses       map[string]*Response       `json:"responses,omitempty" yaml:"responses,omitempty"`
+}
+
+// SecurityScheme used by secured operations
+type SecurityScheme struct {
+	Type         string `json:"type" yaml:"type"`
+	Scheme       string `json:"scheme,omitempty" yaml:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty" yaml:"bearerFormat,omitempty"`
+	Description  string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// Config for document generation
+type Config struct {
+	Info    Info
+	Servers []Server
+	// SecurityScheme name referenced by routes with Access or Policy, defaults to bearerAuth
+	SecurityScheme string
+	// Security scheme definition, defaults to a JWT bearer scheme
+	Security *SecurityScheme
+}
+
+// Generate document from HTTP routes. Access roles become the security requirement's
+// scopes, Request structs become parameters and a JSON request body and Response values
+// become the success response schema
+func Generate(routes lambdaHTTP.Routes, config Config) *Document {
+	g := &generator{config: config, reflector: schema.NewReflector(refPrefix)}
+	if g.config.SecurityScheme == "" {
+		g.config.SecurityScheme = "bearerAuth"
+	}
+	if g.config.Security == nil {
+		g.config.Security = &SecurityScheme{Type: "http", Scheme: "bearer", BearerFormat: "JWT"}
+	}
+	return g.document(routes)
+}
+
+type generator struct {
+	config    Config
+	reflector *schema.Reflector
+	secured   bool
+	errors    bool
+}
+
+func (g *generator) document(routes lambdaHTTP.Routes) *Document {
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    g.config.Info,
+		Servers: g.config.Servers,
+		Paths:   map[string]PathItem{},
+	}
+
+	paths := make([]string, 0, len(routes))
+	for path := range routes {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		item := PathItem{}
+		methods := make([]string, 0, len(routes[path]))
+		for method := range routes[path] {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+
+		for _, method := range methods {
+			route := routes[path][method]
+			if method == "ANY" {
+				for _, m := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+					item[strings.ToLower(m)] = g.operation(m, path, route)
+				}
+				continue
+			}
+			item[strings.ToLower(method)] = g.operation(method, path, route)
+		}
+		doc.Paths[openAPIPath(path)] = item
+	}
+
+	if g.errors {
+		g.reflector.Definitions[ProblemSchema] = problemSchema()
+	}
+	if len(g.reflector.Definitions) > 0 {
+		doc.Components.Schemas = g.reflector.Definitions
+	}
+	if g.secured {
+		doc.Components.SecuritySchemes = map[string]*SecurityScheme{g.config.SecurityScheme: g.config.Security}
+	}
+	return doc
+}
+
+func (g *generator) operation(method string, path string, route lambdaHTTP.Route) *Operation {
+	op := &Operation{OperationID: operationID(method, path), Responses: map[string]*Response{}}
+	if doc := route.Doc; doc != nil {
+		op.Summary, op.Description, op.Tags = doc.Summary, doc.Description, doc.Tags
+		if doc.OperationID != "" {
+			op.OperationID = doc.OperationID
+		}
+	}
+
+	op.Parameters = g.parameters(path, route.Request)
+	if body := g.requestBody(method, route); body != nil {
+		op.RequestBody = body
+	}
+	g.responses(op, route)
+	if route.Access != nil || route.Policy != nil {
+		g.secured = true
+		scopes := []string{}
+		if route.Access != nil {
+			scopes = append(scopes, route.Access.Roles...)
+		}
+		op.Security = []map[string][]string{{g.config.SecurityScheme: scopes}}
+		g.errorResponse(op, http.StatusUnauthorized)
+		g.errorResponse(op, http.StatusForbidden)
+	}
+	return op
+}
+
+// Path params from template, query and header params from Request struct tags
+func (g *generator) parameters(path string, request interface{}) []*Parameter {
+	fields := map[string]reflect.StructField{}
+	var params []*Parameter
+	if t := structType(request); t != nil {
+		for _, field := range flatFields(t) {
+			for _, in := range []string{"path", "query", "header"} {
+				name, ok := field.Tag.Lookup(in)
+				if !ok || name == "-" {
+					continue
+				}
+				if name == "" {
+					name = field.Name
+				}
+				if in == "path" {
+					fields[name] = field
+					continue
+				}
+				s := g.reflector.ReflectType(field.Type)
+				required := schema.Constrain(s, field.Tag.Get("validate"))
+				params = append(params, &Parameter{Name: name, In: in, Required: required, Schema: s})
+			}
+		}
+	}
+
+	var pathParams []*Parameter
+	for _, match := range pathParam.FindAllStringSubmatch(path, -1) {
+		s := &schema.Schema{Type: "string"}
+		if field, ok := fields[match[1]]; ok {
+			s = g.reflector.ReflectType(field.Type)
+			schema.Constrain(s, field.Tag.Get("validate"))
+		}
+		pathParams = append(pathParams, &Parameter{Name: match[1], In: "path", Required: true, Schema: s})
+	}
+	return append(pathParams, params...)
+}
+
+func (g *generator) requestBody(method string, route lambdaHTTP.Route) *RequestBody {
+	if !hasBody(route.Request) || method == http.MethodGet || method == http.MethodHead || method == http.MethodDelete {
+		return nil
+	}
+
+	s := g.reflector.Reflect(route.Request)
+	resolved := s
+	if def, ok := g.reflector.Definitions[strings.TrimPrefix(s.Ref, refPrefix)]; ok {
+		resolved = def
+	}
+
+	content := map[string]*MediaType{}
+	consumes := route.Consumes
+	if len(consumes) == 0 {
+		consumes = []string{lambdaHTTP.MediaTypeJSON}
+	}
+	for _, mediaType := range consumes {
+		content[mediaType] = &MediaType{Schema: s}
+	}
+	return &RequestBody{Required: len(resolved.Required) > 0, Content: content}
+}
+
+func (g *generator) responses(op *Operation, route lambdaHTTP.Route) {
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	res := &Response{Description: http.StatusText(status)}
+	if status != http.StatusNoContent && route.Response != nil {
+		produces := route.Produces
+		if len(produces) == 0 {
+			produces = []string{lambdaHTTP.MediaTypeJSON}
+		}
+		s := g.reflector.Reflect(route.Response)
+		res.Content = map[string]*MediaType{}
+		for _, mediaType := range produces {
+			res.Content[mediaType] = &MediaType{Schema: s}
+		}
+	}
+	op.Responses[strconv.Itoa(status)] = res
+
+	if route.Request != nil {
+		g.errorResponse(op, http.StatusBadRequest)
+		g.errorResponse(op, http.StatusUnprocessableEntity)
+	}
+}
+
+func (g *generator) errorResponse(op *Operation, status int) {
+	g.errors = true
+	op.Responses[strconv.Itoa(status)] = &Response{
+		Description: http.StatusText(status),
+		Content: map[string]*MediaType{
+			lambdaHTTP.MediaTypeProblemJSON: {Schema: &schema.Schema{Ref: refPrefix + ProblemSchema}},
+		},
+	}
+}
+
+// JSON encoded document
+func (d *Document) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
+
+// YAML encoded document
+func (d *Document) YAML() ([]byte, error) {
+	return yaml.Marshal(d)
+}
+
+// RFC 7807 problem details as responded by http.ProblemFormatter
+func problemSchema() *schema.Schema {
+	return &schema.Schema{
+		Type: "object",
+		Properties: map[string]*schema.Schema{
+			"type":     {Type: "string"},
+			"title":    {Type: "string"},
+			"status":   {Type: "integer", Format: "int64"},
+			"detail":   {Type: "string"},
+			"instance": {Type: "string"},
+		},
+		Required: []string{"status", "title", "type"},
+	}
+}
+
+// Operation id from method and path, e.g. GET /users/{id} becomes getUsersById
+func operationID(method string, path string) string {
+	id := strings.ToLower(method)
+	for _, segment := range strings.Split(path, "/") {
+		if match := pathParam.FindStringSubmatch(segment); match != nil {
+			id += "By" + title(match[1])
+			continue
+		}
+		for _, word := range strings.FieldsFunc(segment, func(r rune) bool { return r == '-' || r == '_' || r == '.' }) {
+			id += title(word)
+		}
+	}
+	return id
+}
+
+// Greedy path params like {proxy+} are not valid OpenAPI templates
+func openAPIPath(path string) string {
+	return strings.ReplaceAll(path, "+}", "}")
+}
+
+func title(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func structType(v interface{}) reflect.Type {
+	if v == nil {
+		return nil
+	}
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	return t
+}
+
+// Request has fields decoded from the body, other values are always bodies
+func hasBody(request interface{}) bool {
+	if request == nil {
+		return false
+	}
+	t := structType(request)
+	if t == nil {
+		return true
+	}
+	for _, field := range flatFields(t) {
+		if !schema.IsParam(field) && schema.JSONName(field) != "-" {
+			return true
+		}
+	}
+	return false
+}
+
+// Fields of t including those of embedded structs
+func flatFields(t reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+	for n := 0; n < t.NumField(); n++ {
+		field := t.Field(n)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			fields = append(fields, flatFields(field.Type)...)
+			continue
+		}
+		if field.PkgPath == "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,216 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	lambdaHTTP "github.com/matthisstenius/lambda-router/v4/http"
+	"github.com/matthisstenius/lambda-router/v4/schema"
+)
+
+// Validator checks requests and responses against the operations of a document, for use
+// with http.WithRequestValidator and http.WithResponseValidator. Requests to operations
+// missing from the document are not checked
+type Validator struct {
+	doc     *Document
+	schemas *schema.Validator
+}
+
+// NewValidator initializer
+func NewValidator(doc *Document) *Validator {
+	return &Validator{
+		doc:     doc,
+		schemas: &schema.Validator{RefPrefix: refPrefix, Definitions: doc.Components.Schemas},
+	}
+}
+
+// ValidateRequest path params, query params, headers and JSON body against the
+// operation. Violations are returned as a 400 error with the invalid fields as errors
+// extension and unsupported body media types as a 415 error
+func (v *Validator) ValidateRequest(i *lambdaHTTP.Input, method string, resource string) error {
+	op := v.operation(method, resource)
+	if op == nil {
+		return nil
+	}
+
+	var fields []domain.FieldError
+	for _, param := range op.Parameters {
+		value, ok := paramValue(i, param)
+		if !ok {
+			if param.Required {
+				fields = append(fields, domain.FieldError{Field: param.Name, Source: param.In, Path: param.Name, Message: "is required"})
+			}
+			continue
+		}
+		fields = append(fields, v.validateParam(param, value)...)
+	}
+
+	bodyFields, err := v.validateBody(i, op.RequestBody)
+	if err != nil {
+		return err
+	}
+	fields = append(fields, bodyFields...)
+
+	if len(fields) > 0 {
+		return lambdaHTTP.BadRequest("Request does not match specification").With("errors", fields)
+	}
+	return nil
+}
+
+// ValidateResponse status, media type and JSON body against the operation's documented
+// responses
+func (v *Validator) ValidateResponse(i *lambdaHTTP.Input, method string, resource string, status int, contentType string, body []byte) error {
+	op := v.operation(method, resource)
+	if op == nil {
+		return nil
+	}
+
+	res := response(op, status)
+	if res == nil {
+		return fmt.Errorf("status %d is not documented", status)
+	}
+	if len(body) == 0 {
+		return nil
+	}
+	if len(res.Content) == 0 {
+		return fmt.Errorf("status %d is documented without a body", status)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	content := mediaTypeContent(res.Content, mediaType)
+	if content == nil {
+		return fmt.Errorf("content type %q is not documented for status %d", mediaType, status)
+	}
+	if !isJSON(mediaType) || content.Schema == nil {
+		return nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Errorf("could not parse body as JSON: %w", err)
+	}
+	if fields := v.schemas.Validate(content.Schema, value); len(fields) > 0 {
+		msgs := make([]string, len(fields))
+		for n, f := range fields {
+			msgs[n] = strings.TrimSpace(f.Path + " " + f.Message)
+		}
+		return fmt.Errorf("invalid body: %s", strings.Join(msgs, "; "))
+	}
+	return nil
+}
+
+func (v *Validator) operation(method string, resource string) *Operation {
+	item, ok := v.doc.Paths[openAPIPath(resource)]
+	if !ok {
+		return nil
+	}
+	return item[strings.ToLower(method)]
+}
+
+func (v *Validator) validateParam(param *Parameter, value string) []domain.FieldError {
+	if param.Schema == nil {
+		return nil
+	}
+	coerced, err := v.schemas.Coerce(param.Schema, value)
+	if err != nil {
+		return []domain.FieldError{{Field: param.Name, Source: param.In, Path: param.Name, Message: err.Error()}}
+	}
+
+	fields := v.schemas.Validate(param.Schema, coerced)
+	for n := range fields {
+		fields[n].Field = param.Name
+		fields[n].Source = param.In
+		fields[n].Path = param.Name + fields[n].Path
+	}
+	return fields
+}
+
+func (v *Validator) validateBody(i *lambdaHTTP.Input, requestBody *RequestBody) ([]domain.FieldError, error) {
+	if requestBody == nil {
+		return nil, nil
+	}
+	body, err := i.Body()
+	if err != nil {
+		return nil, err
+	}
+	if len(body) == 0 {
+		if requestBody.Required {
+			return []domain.FieldError{{Source: lambdaHTTP.SourceBody, Message: "is required"}}, nil
+		}
+		return nil, nil
+	}
+
+	// Bodies without Content-Type are decoded as JSON, as by http.Input.Bind
+	mediaType := i.ContentType()
+	if mediaType == "" {
+		mediaType = lambdaHTTP.MediaTypeJSON
+	}
+	content := mediaTypeContent(requestBody.Content, mediaType)
+	if content == nil {
+		return nil, lambdaHTTP.NewError(http.StatusUnsupportedMediaType, "Unsupported media type")
+	}
+	if !isJSON(mediaType) || content.Schema == nil {
+		return nil, nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return []domain.FieldError{{Source: lambdaHTTP.SourceBody, Message: "could not parse body as JSON"}}, nil
+	}
+	fields := v.schemas.Validate(content.Schema, value)
+	for n := range fields {
+		fields[n].Source = lambdaHTTP.SourceBody
+	}
+	return fields, nil
+}
+
+// Param value from path, query or header, cookie params are not checked
+func paramValue(i *lambdaHTTP.Input, param *Parameter) (string, bool) {
+	switch param.In {
+	case "path":
+		return i.GetPathParam(param.Name), i.HasPathParam(param.Name)
+	case "query":
+		return i.GetQueryParam(param.Name), i.HasQueryParam(param.Name)
+	case "header":
+		value := i.GetHeader(param.Name)
+		return value, value != ""
+	}
+	return "", false
+}
+
+// Response documented for status, falling back to its range, e.g. 4XX, and default
+func response(op *Operation, status int) *Response {
+	code := strconv.Itoa(status)
+	for _, key := range []string{code, code[:1] + "XX", "default"} {
+		if res, ok := op.Responses[key]; ok {
+			return res
+		}
+	}
+	return nil
+}
+
+// Content for media type, falling back to wildcards such as application/* and */*
+func mediaTypeContent(content map[string]*MediaType, mediaType string) *MediaType {
+	mediaType = strings.ToLower(mediaType)
+	candidates := []string{mediaType, "*/*"}
+	if idx := strings.Index(mediaType, "/"); idx >= 0 {
+		candidates = []string{mediaType, mediaType[:idx] + "/*", "*/*"}
+	}
+	for _, candidate := range candidates {
+		for key, value := range content {
+			if strings.EqualFold(key, candidate) {
+				return value
+			}
+		}
+	}
+	return nil
+}
+
+func isJSON(mediaType string) bool {
+	return mediaType == lambdaHTTP.MediaTypeJSON || strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,205 @@
+package redact
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Mask default replacement for redacted values
+const Mask = "[REDACTED]"
+
+// Redactor masks sensitive data in events and responses before they are logged
+type Redactor struct {
+	// Headers denylist, matched case-insensitively in headers and multiValueHeaders
+	Headers []string
+	// Fields denylist, matched case-insensitively on keys at any depth of the event
+	Fields []string
+	// BodyPaths JSON paths masked in JSON encoded bodies, e.g. $.user.password or $.items[*].card
+	BodyPaths []string
+	// MaxLength truncates string values longer than this, 0 disables truncation
+	MaxLength int
+	// Mask replacement for redacted values, defaults to Mask
+	Mask string
+}
+
+// Default Redactor masking credentials, authorizer claims, authorizer identity sources
+// and passwords
+func Default() *Redactor {
+	return &Redactor{
+		Headers:   []string{"Authorization", "Cookie", "Set-Cookie", "X-Api-Key", "X-Amz-Security-Token"},
+		Fields:    []string{"claims", "authorizationToken", "identitySource"},
+		BodyPaths: []string{"$.password"},
+		MaxLength: 4096,
+	}
+}
+
+// Event redacted copy of event, the given event is left untouched
+func (r *Redactor) Event(evt map[string]interface{}) map[string]interface{} {
+	return r.Payload(evt).(map[string]interface{})
+}
+
+// Payload redacted copy of any event or response payload
+func (r *Redactor) Payload(payload interface{}) interface{} {
+	return r.walk(payload)
+}
+
+func (r *Redactor) walk(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			switch {
+			case r.isField(k):
+				out[k] = r.mask()
+			case k == "headers" || k == "multiValueHeaders":
+				out[k] = r.headers(val)
+			case k == "body":
+				out[k] = r.body(val)
+			default:
+				out[k] = r.walk(val)
+			}
+		}
+		return out
+	case map[string]string:
+		out := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			out[k] = val
+		}
+		return r.walk(out)
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, val := range v {
+			out[i] = r.walk(val)
+		}
+		return out
+	case string:
+		return r.truncate(v)
+	default:
+		return value
+	}
+}
+
+func (r *Redactor) headers(value interface{}) interface{} {
+	var headers map[string]interface{}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		headers = v
+	case map[string]string:
+		headers = make(map[string]interface{}, len(v))
+		for k, val := range v {
+			headers[k] = val
+		}
+	default:
+		return r.walk(value)
+	}
+
+	out := make(map[string]interface{}, len(headers))
+	for k, val := range headers {
+		if r.isHeader(k) || r.isField(k) {
+			out[k] = r.mask()
+			continue
+		}
+		out[k] = r.walk(val)
+	}
+	return out
+}
+
+func (r *Redactor) body(value interface{}) interface{} {
+	body, ok := value.(string)
+	if !ok {
+		return r.walk(value)
+	}
+	if len(r.BodyPaths) == 0 {
+		return r.truncate(body)
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(body), &decoded); err != nil {
+		return r.truncate(body)
+	}
+	for _, path := range r.BodyPaths {
+		decoded = r.maskPath(decoded, parsePath(path))
+	}
+	encoded, err := json.Marshal(decoded)
+	if err != nil {
+		return r.truncate(body)
+	}
+	return r.truncate(string(encoded))
+}
+
+func (r *Redactor) maskPath(value interface{}, path []string) interface{} {
+	if len(path) == 0 {
+		return r.mask()
+	}
+
+	segment, rest := path[0], path[1:]
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			if segment == "*" || k == segment {
+				v[k] = r.maskPath(val, rest)
+			}
+		}
+	case []interface{}:
+		for i, val := range v {
+			if segment == "*" || strconv.Itoa(i) == segment {
+				v[i] = r.maskPath(val, rest)
+			}
+		}
+	}
+	return value
+}
+
+func (r *Redactor) truncate(value string) string {
+	if r.MaxLength <= 0 || len(value) <= r.MaxLength {
+		return value
+	}
+	// Cut on a rune boundary to keep the logged value valid UTF-8
+	end := r.MaxLength
+	for end > 0 && !utf8.RuneStart(value[end]) {
+		end--
+	}
+	return fmt.Sprintf("%s...[TRUNCATED %d bytes]", value[:end], len(value)-end)
+}
+
+func (r *Redactor) isHeader(key string) bool {
+	for _, h := range r.Headers {
+		if strings.EqualFold(h, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Redactor) isField(key string) bool {
+	for _, f := range r.Fields {
+		if strings.EqualFold(f, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Redactor) mask() string {
+	if r.Mask == "" {
+		return Mask
+	}
+	return r.Mask
+}
+
+// Parses JSON path like $.items[*].card into its segments
+func parsePath(path string) []string {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	path = strings.NewReplacer("[", ".", "]", "").Replace(path)
+
+	var segments []string
+	for _, s := range strings.Split(path, ".") {
+		if s != "" {
+			segments = append(segments, s)
+		}
+	}
+	return segments
+}
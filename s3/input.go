@@ -1,16 +1,34 @@
 package s3
 
-import "strings"
+import (
+	"context"
+	"strings"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/logging"
+)
 
 // Input for parsed S3 event
 // TODO: Write tests
 type Input struct {
-	event map[string]interface{}
+	event  map[string]interface{}
+	logger domain.Logger
+	ctx    context.Context
 }
 
 // NewInput initializer
 func NewInput(e map[string]interface{}) *Input {
-	return &Input{event: e}
+	return &Input{event: e, logger: logging.Default(), ctx: context.Background()}
+}
+
+// Context for current invocation
+func (i *Input) Context() context.Context {
+	return i.ctx
+}
+
+// Logger for current event
+func (i *Input) Logger() domain.Logger {
+	return i.logger
 }
 
 // ObjectKeyPath extract full object key path
@@ -24,3 +42,15 @@ func (i *Input) ObjectKey() string {
 	fragments := strings.Split(i.ObjectKeyPath(), "/")
 	return fragments[len(fragments)-1]
 }
+
+// CorrelationID from S3 request id of the operation that triggered the event
+func (i *Input) CorrelationID() string {
+	records, ok := i.event["Records"].([]interface{})
+	if !ok || len(records) == 0 {
+		return ""
+	}
+	record, _ := records[0].(map[string]interface{})
+	elements, _ := record["responseElements"].(map[string]interface{})
+	id, _ := elements["x-amz-request-id"].(string)
+	return id
+}
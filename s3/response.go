@@ -1,9 +1,9 @@
 package s3
 
-import "github.com/matthisstenius/logger"
-
 // Response for S3 event
-type Response struct{}
+type Response struct {
+	message string
+}
 
 // Payload formatted response data
 func (r *Response) Payload() interface{} {
@@ -12,6 +12,5 @@ func (r *Response) Payload() interface{} {
 
 // NewResponse initializer
 func NewResponse(message string) *Response {
-	logger.WithFields(logger.Fields{"message": message}).Info("Response::NewResponse() S3 handler responded")
-	return &Response{}
+	return &Response{message: message}
 }
@@ -1,9 +1,13 @@
 package s3
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/logging"
 	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -19,28 +23,64 @@ type Routes map[string]Route
 
 // Router for S3 events
 type Router struct {
-	routes Routes
+	routes       Routes
+	logging      domain.Logging
+	logger       domain.Logger
+	customLogger bool
+}
+
+// Option for configuring Router
+type Option func(r *Router)
+
+// WithLogging levels for incoming events and outgoing responses
+func WithLogging(logging domain.Logging) Option {
+	return func(r *Router) {
+		r.logging = logging
+	}
+}
+
+// WithLogger for routing and handler logging, defaults to logging.Default()
+func WithLogger(logger domain.Logger) Option {
+	return func(r *Router) {
+		r.logger = logger
+		r.customLogger = true
+	}
+}
+
+// DefaultLogger replaces logging.Default() unless a logger was set with WithLogger, used
+// by router.Event for Config.Logger
+func (r *Router) DefaultLogger(logger domain.Logger) {
+	if !r.customLogger {
+		r.logger = logger
+	}
 }
 
 // NewRouter initializer
-func NewRouter(routes Routes) *Router {
-	return &Router{routes: routes}
+func NewRouter(routes Routes, opts ...Option) *Router {
+	r := &Router{routes: routes, logger: logging.Default()}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 // Route incoming event to corresponding handler
 func (r *Router) Route(evt map[string]interface{}) (domain.Response, error) {
-	record := evt["Records"].([]interface{})[0].(map[string]interface{})
-	key := record["s3"].(map[string]interface{})["object"].(map[string]interface{})["key"].(string)
-
-	re := regexp.MustCompile("[^/]+$")
-	folder := re.ReplaceAllString(key, "")
-	folder = "/" + strings.TrimSuffix(folder, "/")
+	return r.RouteContext(context.Background(), evt)
+}
 
-	route, ok := r.routes[folder]
+// RouteContext routes incoming event to corresponding handler with invocation context
+func (r *Router) RouteContext(ctx context.Context, evt map[string]interface{}) (domain.Response, error) {
+	route, ok := r.routes[r.folder(evt)]
 	if !ok {
 		return nil, errors.New("handler func missing")
 	}
-	return route.Handler(NewInput(evt)), nil
+	i := NewInput(evt)
+	i.ctx = ctx
+	i.logger = r.logger.With(domain.Fields{domain.CorrelationIDField: i.CorrelationID()})
+	res := route.Handler(i)
+	r.logResponse(i.logger, res)
+	return res, nil
 }
 
 // IsMatch for S3 event
@@ -50,3 +90,68 @@ func (r *Router) IsMatch(e map[string]interface{}) bool {
 	}
 	return false
 }
+
+// Logging levels for S3 events
+func (r *Router) Logging() domain.Logging {
+	return r.logging
+}
+
+// RouteKey folder prefix of the object key
+func (r *Router) RouteKey(evt map[string]interface{}) string {
+	return r.folder(evt)
+}
+
+func (r *Router) folder(evt map[string]interface{}) string {
+	record := evt["Records"].([]interface{})[0].(map[string]interface{})
+	key := record["s3"].(map[string]interface{})["object"].(map[string]interface{})["key"].(string)
+
+	re := regexp.MustCompile("[^/]+$")
+	folder := re.ReplaceAllString(key, "")
+	return "/" + strings.TrimSuffix(folder, "/")
+}
+
+// CorrelationID for S3 event
+func (r *Router) CorrelationID(evt map[string]interface{}) string {
+	return NewInput(evt).CorrelationID()
+}
+
+func (r *Router) logResponse(logger domain.Logger, res domain.Response) {
+	if res, ok := res.(*Response); ok {
+		logger.Info("Router::Route() S3 handler responded", domain.Fields{"message": res.message})
+	}
+}
+
+// Routes registered in router sorted by prefix
+func (r *Router) Routes() []domain.RouteInfo {
+	routes := make([]domain.RouteInfo, 0, len(r.routes))
+	for _, key := range r.keys() {
+		routes = append(routes, domain.RouteInfo{Source: domain.SourceS3, Key: key})
+	}
+	return routes
+}
+
+// Validate route table, reporting empty keys and missing handlers
+func (r *Router) Validate() error {
+	var errs []error
+	for _, key := range r.keys() {
+		if key == "" {
+			errs = append(errs, errors.New("route with empty prefix"))
+		}
+		if key != "" && !strings.HasPrefix(key, "/") {
+			errs = append(errs, fmt.Errorf("route %s: prefix must start with /", key))
+		}
+		if r.routes[key].Handler == nil {
+			errs = append(errs, fmt.Errorf("route %s: handler missing", key))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Router) keys() []string {
+	keys := make([]string, 0, len(r.routes))
+	for key := range r.routes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
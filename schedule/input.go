@@ -0,0 +1,65 @@
+package schedule
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/logging"
+	"github.com/matthisstenius/lambda-router/v4/metrics"
+)
+
+// Input for parsed Schedule event
+type Input struct {
+	event  map[string]interface{}
+	logger domain.Logger
+	ctx    context.Context
+}
+
+// NewInput initializer
+func NewInput(e map[string]interface{}) *Input {
+	return &Input{event: e, logger: logging.Default(), ctx: context.Background()}
+}
+
+// Context for current invocation, carrying trace context
+func (i *Input) Context() context.Context {
+	return i.ctx
+}
+
+// Logger for current event
+func (i *Input) Logger() domain.Logger {
+	return i.logger
+}
+
+// Metrics document for current invocation, see metrics.FromContext
+func (i *Input) Metrics() *metrics.Metrics {
+	return metrics.FromContext(i.ctx)
+}
+
+// Rule schedule rule resource of event
+func (i *Input) Rule() string {
+	rule, _ := i.event["resource"].(string)
+	return rule
+}
+
+// ParseDetail of EventBridge event as JSON
+func (i *Input) ParseDetail(out interface{}) error {
+	encoded, err := json.Marshal(i.event["detail"])
+	if err == nil {
+		err = json.Unmarshal(encoded, out)
+	}
+	if err != nil {
+		i.logger.Error("ScheduleInput::ParseDetail() could not unmarshal json", domain.Fields{
+			"error": err,
+		})
+		return errors.New("invalid event detail")
+	}
+	return nil
+}
+
+// CorrelationID from EventBridge event id
+func (i *Input) CorrelationID() string {
+	id, _ := i.event["id"].(string)
+	return id
+}
@@ -1,9 +1,10 @@
 package schedule
 
-import "github.com/matthisstenius/logger"
-
 // Response for schedule event
-type Response struct{}
+type Response struct {
+	message string
+	err     error
+}
 
 // Payload formatted response data
 func (r *Response) Payload() interface{} {
@@ -12,6 +13,10 @@ func (r *Response) Payload() interface{} {
 
 // NewResponse initializer
 func NewResponse(message string) *Response {
-	logger.WithFields(logger.Fields{"message": message}).Info("Response::NewResponse() schedule handler responded")
-	return &Response{}
+	return &Response{message: message}
+}
+
+// NewErrorResponse failing the invocation with err so that Lambda retries the event
+func NewErrorResponse(err error) *Response {
+	return &Response{message: err.Error(), err: err}
 }
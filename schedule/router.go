@@ -1,15 +1,23 @@
 package schedule
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/logging"
+	"sort"
 )
 
 const EventSource = "schedule"
 
-// Route mapping for handler
+// Route mapping for handler, InputHandler is used instead of Handler when set
 type Route struct {
 	Handler func() domain.Response
+	// InputHandler receives the invocation context, correlated logger and metrics
+	InputHandler func(i *Input) domain.Response
+	// Detail value documenting the decoded event detail type, set by Typed
+	Detail interface{}
 }
 
 // Routes mappings for Schedule handlers
@@ -17,25 +25,136 @@ type Routes map[string]Route
 
 // Router for Schedule events
 type Router struct {
-	routes Routes
+	routes       Routes
+	logging      domain.Logging
+	logger       domain.Logger
+	customLogger bool
+}
+
+// Option for configuring Router
+type Option func(r *Router)
+
+// WithLogging levels for incoming events and outgoing responses
+func WithLogging(logging domain.Logging) Option {
+	return func(r *Router) {
+		r.logging = logging
+	}
+}
+
+// WithLogger for routing and handler logging, defaults to logging.Default()
+func WithLogger(logger domain.Logger) Option {
+	return func(r *Router) {
+		r.logger = logger
+		r.customLogger = true
+	}
+}
+
+// DefaultLogger replaces logging.Default() unless a logger was set with WithLogger, used
+// by router.Event for Config.Logger
+func (r *Router) DefaultLogger(logger domain.Logger) {
+	if !r.customLogger {
+		r.logger = logger
+	}
 }
 
 // NewRouter initializer
-func NewRouter(routes Routes) *Router {
-	return &Router{routes: routes}
+func NewRouter(routes Routes, opts ...Option) *Router {
+	r := &Router{routes: routes, logger: logging.Default()}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 // Route incoming event to corresponding handler
 func (r *Router) Route(evt map[string]interface{}) (domain.Response, error) {
-	resource := evt["resource"].(string)
-	route, found := r.routes[resource]
+	return r.RouteContext(context.Background(), evt)
+}
+
+// RouteContext routes incoming event to corresponding handler with invocation context.
+// Handlers responding NewErrorResponse make the router return their error
+func (r *Router) RouteContext(ctx context.Context, evt map[string]interface{}) (domain.Response, error) {
+	route, found := r.routes[r.RouteKey(evt)]
 	if !found {
 		return nil, errors.New("handler func missing")
 	}
-	return route.Handler(), nil
+	i := NewInput(evt)
+	i.ctx = ctx
+	i.logger = r.logger.With(domain.Fields{domain.CorrelationIDField: i.CorrelationID()})
+
+	var res domain.Response
+	if route.InputHandler != nil {
+		res = route.InputHandler(i)
+	} else {
+		res = route.Handler()
+	}
+	r.logResponse(i.logger, res)
+	if res, ok := res.(*Response); ok && res.err != nil {
+		return nil, res.err
+	}
+	return res, nil
 }
 
 // IsMatch for Schedule event
 func (r *Router) IsMatch(e map[string]interface{}) bool {
 	return e["eventSource"] == EventSource
 }
+
+// Logging levels for Schedule events
+func (r *Router) Logging() domain.Logging {
+	return r.logging
+}
+
+// RouteKey schedule rule resource
+func (r *Router) RouteKey(evt map[string]interface{}) string {
+	return evt["resource"].(string)
+}
+
+// CorrelationID from EventBridge event id
+func (r *Router) CorrelationID(evt map[string]interface{}) string {
+	return NewInput(evt).CorrelationID()
+}
+
+func (r *Router) logResponse(logger domain.Logger, res domain.Response) {
+	response, ok := res.(*Response)
+	if !ok {
+		return
+	}
+	if response.err != nil {
+		logger.Error("Router::Route() schedule handler failed", domain.Fields{"error": response.err.Error()})
+		return
+	}
+	logger.Info("Router::Route() schedule handler responded", domain.Fields{"message": response.message})
+}
+
+// Routes registered in router sorted by rule
+func (r *Router) Routes() []domain.RouteInfo {
+	routes := make([]domain.RouteInfo, 0, len(r.routes))
+	for _, key := range r.keys() {
+		routes = append(routes, domain.RouteInfo{Source: domain.SourceSchedule, Key: key, Payload: r.routes[key].Detail})
+	}
+	return routes
+}
+
+// Validate route table, reporting empty keys and missing handlers
+func (r *Router) Validate() error {
+	var errs []error
+	for _, key := range r.keys() {
+		if key == "" {
+			errs = append(errs, errors.New("route with empty rule"))
+		}
+		if r.routes[key].Handler == nil && r.routes[key].InputHandler == nil {
+			errs = append(errs, fmt.Errorf("route %s: handler missing", key))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Router) keys() []string {
+	keys := make([]string, 0, len(r.routes))
+	for key := range r.routes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
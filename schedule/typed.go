@@ -0,0 +1,27 @@
+package schedule
+
+import (
+	"context"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+)
+
+// Typed Route decoding the EventBridge event detail once before calling fn. Details that
+// can not be decoded are logged and acknowledged as retrying would not help, errors
+// returned by fn fail the invocation so that the event is retried
+func Typed[Detail any](fn func(ctx context.Context, detail Detail) error) Route {
+	var detail Detail
+	return Route{
+		Detail: detail,
+		InputHandler: func(i *Input) domain.Response {
+			var detail Detail
+			if err := i.ParseDetail(&detail); err != nil {
+				return NewResponse("invalid event discarded")
+			}
+			if err := fn(i.Context(), detail); err != nil {
+				return NewErrorResponse(err)
+			}
+			return NewResponse("event handled")
+		},
+	}
+}
@@ -0,0 +1,338 @@
+package schema
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema JSON Schema subset used by OpenAPI 3.1 and AsyncAPI documents
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty" yaml:"type,omitempty"`
+	Format               string             `json:"format,omitempty" yaml:"format,omitempty"`
+	Description          string             `json:"description,omitempty" yaml:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty" yaml:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty" yaml:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+	Pattern              string             `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty" yaml:"allOf,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
+	Not                  *Schema            `json:"not,omitempty" yaml:"not,omitempty"`
+	// Nullable OpenAPI 3.0 keyword allowing null, expressed as a type array in 3.1
+	Nullable bool `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+}
+
+// UnmarshalJSON schema, boolean schemas such as additionalProperties: false are decoded
+// as {} for true and {"not": {}} for false and type arrays such as ["string", "null"]
+// as anyOf with a schema per type
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	var allowed bool
+	if err := json.Unmarshal(data, &allowed); err == nil {
+		*s = Schema{}
+		if !allowed {
+			s.Not = &Schema{}
+		}
+		return nil
+	}
+
+	type plain Schema
+	raw := struct {
+		*plain
+		Type json.RawMessage `json:"type"`
+	}{plain: (*plain)(s)}
+	*s = Schema{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw.Type) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(raw.Type, &s.Type); err == nil {
+		return nil
+	}
+	var types []string
+	if err := json.Unmarshal(raw.Type, &types); err != nil || len(types) == 0 {
+		return fmt.Errorf("type must be a string or a non empty array of strings, got %s", raw.Type)
+	}
+	if len(types) == 1 {
+		s.Type = types[0]
+		return nil
+	}
+	anyOf := make([]*Schema, len(types))
+	for n, t := range types {
+		anyOf[n] = &Schema{Type: t}
+	}
+	if s.AnyOf != nil {
+		// Both type array and anyOf must hold
+		s.AllOf = append(s.AllOf, &Schema{AnyOf: anyOf})
+		return nil
+	}
+	s.AnyOf = anyOf
+	return nil
+}
+
+var (
+	timeType        = reflect.TypeOf(time.Time{})
+	durationType    = reflect.TypeOf(time.Duration(0))
+	textMarshaler   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	invalidNameChar = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+	// Struct tags of fields bound outside of the body
+	paramTags = []string{"path", "query", "header", "form"}
+)
+
+// Reflector builds schemas from Go values, registering named structs as definitions
+// referenced with RefPrefix, e.g. #/components/schemas/
+type Reflector struct {
+	RefPrefix   string
+	Definitions map[string]*Schema
+	names       map[reflect.Type]string
+}
+
+// NewReflector initializer
+func NewReflector(refPrefix string) *Reflector {
+	return &Reflector{RefPrefix: refPrefix, Definitions: map[string]*Schema{}, names: map[reflect.Type]string{}}
+}
+
+// Reflect schema of v's type as decoded from JSON. Struct fields tagged path, query,
+// header or form are left out, validate tags are mapped to schema constraints
+func (r *Reflector) Reflect(v interface{}) *Schema {
+	if v == nil {
+		return &Schema{}
+	}
+	return r.reflectType(reflect.TypeOf(v))
+}
+
+// ReflectType schema of t, see Reflect
+func (r *Reflector) ReflectType(t reflect.Type) *Schema {
+	return r.reflectType(t)
+}
+
+func (r *Reflector) reflectType(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == durationType:
+		return &Schema{Type: "string", Format: "duration"}
+	case t.Kind() != reflect.Struct && reflect.PtrTo(t).Implements(textMarshaler):
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: r.reflectType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.reflectType(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.reflectStruct(t)
+		}
+		return r.ref(t)
+	default:
+		return &Schema{}
+	}
+}
+
+// References named struct, registering its definition on first use
+func (r *Reflector) ref(t reflect.Type) *Schema {
+	name, ok := r.names[t]
+	if !ok {
+		name = r.name(t)
+		r.names[t] = name
+		// Registered before reflecting fields to allow recursive types
+		r.Definitions[name] = &Schema{}
+		*r.Definitions[name] = *r.reflectStruct(t)
+	}
+	return &Schema{Ref: r.RefPrefix + name}
+}
+
+// Unique definition name, qualified by package when names collide
+func (r *Reflector) name(t reflect.Type) string {
+	name := invalidNameChar.ReplaceAllString(t.Name(), "_")
+	if _, taken := r.Definitions[name]; !taken {
+		return name
+	}
+	pkg := t.PkgPath()
+	qualified := invalidNameChar.ReplaceAllString(pkg[strings.LastIndex(pkg, "/")+1:], "_") + "." + name
+	for n := 2; ; n++ {
+		if _, taken := r.Definitions[qualified]; !taken {
+			return qualified
+		}
+		qualified = qualified + strconv.Itoa(n)
+	}
+}
+
+func (r *Reflector) reflectStruct(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	r.reflectFields(t, s)
+	sort.Strings(s.Required)
+	return s
+}
+
+func (r *Reflector) reflectFields(t reflect.Type, s *Schema) {
+	for n := 0; n < t.NumField(); n++ {
+		field := t.Field(n)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			r.reflectFields(field.Type, s)
+			continue
+		}
+		if field.PkgPath != "" || IsParam(field) {
+			continue
+		}
+
+		name := JSONName(field)
+		if name == "-" {
+			continue
+		}
+		property := r.reflectType(field.Type)
+		if Constrain(property, field.Tag.Get("validate")) {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = property
+	}
+}
+
+// IsParam reports whether field is bound from path, query, header or form
+func IsParam(field reflect.StructField) bool {
+	for _, tag := range paramTags {
+		if name, ok := field.Tag.Lookup(tag); ok && name != "-" {
+			return true
+		}
+	}
+	return false
+}
+
+// JSONName of field as encoded by encoding/json, - for ignored fields
+func JSONName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// Constrain s with rules of a validate tag, reporting whether the field is required.
+// Rules after dive constrain array items
+func Constrain(s *Schema, tag string) bool {
+	required := false
+	target := s
+	for tag != "" {
+		var part string
+		if strings.HasPrefix(tag, "regex=") {
+			part, tag = tag, ""
+		} else if idx := strings.Index(tag, ","); idx >= 0 {
+			part, tag = tag[:idx], tag[idx+1:]
+		} else {
+			part, tag = tag, ""
+		}
+
+		name, param := strings.TrimSpace(part), ""
+		if idx := strings.Index(name, "="); idx >= 0 {
+			name, param = name[:idx], name[idx+1:]
+		}
+		switch name {
+		case "dive":
+			if s.Items != nil {
+				target = s.Items
+			}
+		case "required":
+			if target == s {
+				required = true
+			}
+		case "min", "max", "len":
+			target.limit(name, param)
+		case "oneof":
+			for _, option := range strings.Fields(param) {
+				target.Enum = append(target.Enum, enumValue(target.Type, option))
+			}
+		case "email":
+			target.Format = "email"
+		case "uuid":
+			target.Format = "uuid"
+		case "regex":
+			target.Pattern = param
+		}
+	}
+	return required
+}
+
+func (s *Schema) limit(rule string, param string) {
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	length := int(value)
+
+	switch s.Type {
+	case "integer", "number":
+		if rule != "max" {
+			s.Minimum = &value
+		}
+		if rule != "min" {
+			s.Maximum = &value
+		}
+	case "string":
+		if rule != "max" {
+			s.MinLength = &length
+		}
+		if rule != "min" {
+			s.MaxLength = &length
+		}
+	case "array":
+		if rule != "max" {
+			s.MinItems = &length
+		}
+		if rule != "min" {
+			s.MaxItems = &length
+		}
+	}
+}
+
+func enumValue(schemaType string, option string) interface{} {
+	switch schemaType {
+	case "integer":
+		if n, err := strconv.ParseInt(option, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if f, err := strconv.ParseFloat(option, 64); err == nil {
+			return f
+		}
+	}
+	return option
+}
@@ -0,0 +1,350 @@
+package schema
+
+import (
+	"fmt"
+	"math"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+)
+
+var (
+	uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	patterns    sync.Map
+)
+
+// Validator checks values decoded by encoding/json against schemas, resolving $ref with
+// RefPrefix from Definitions. Supports allOf, anyOf, oneOf, not and nullable, unresolved
+// references and unknown formats are not checked
+type Validator struct {
+	RefPrefix   string
+	Definitions map[string]*Schema
+}
+
+// Validate value against s, violations are reported with their path within value,
+// e.g. items[0].name, and the name of the invalid property as field
+func (v *Validator) Validate(s *Schema, value interface{}) []domain.FieldError {
+	var errs []domain.FieldError
+	v.validate(s, value, "", "", &errs)
+	return errs
+}
+
+// Coerce string value of a path, query or header param to the type of s. Arrays are
+// comma separated
+func (v *Validator) Coerce(s *Schema, value string) (interface{}, error) {
+	s = v.resolve(s)
+	if s.Type == "" {
+		// First alternative the value can be coerced to, e.g. integer of [integer, null]
+		for _, alternatives := range [][]*Schema{s.AllOf, s.AnyOf, s.OneOf} {
+			for _, alternative := range alternatives {
+				if t := v.resolve(alternative).Type; t != "" && t != "null" && t != "string" {
+					if coerced, err := v.Coerce(alternative, value); err == nil {
+						return coerced, nil
+					}
+				}
+			}
+		}
+		return value, nil
+	}
+	switch s.Type {
+	case "integer":
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return float64(n), nil
+		}
+		return nil, fmt.Errorf("must be an integer")
+	case "number":
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f, nil
+		}
+		return nil, fmt.Errorf("must be a number")
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b, nil
+		}
+		return nil, fmt.Errorf("must be a boolean")
+	case "array":
+		items := []interface{}{}
+		for _, part := range strings.Split(value, ",") {
+			if s.Items == nil {
+				items = append(items, part)
+				continue
+			}
+			item, err := v.Coerce(s.Items, part)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	}
+	return value, nil
+}
+
+func (v *Validator) resolve(s *Schema) *Schema {
+	for s != nil && s.Ref != "" {
+		def, ok := v.Definitions[strings.TrimPrefix(s.Ref, v.RefPrefix)]
+		if !ok {
+			return &Schema{}
+		}
+		s = def
+	}
+	if s == nil {
+		return &Schema{}
+	}
+	return s
+}
+
+func (v *Validator) validate(s *Schema, value interface{}, path string, field string, errs *[]domain.FieldError) {
+	s = v.resolve(s)
+	fail := func(msg string) {
+		*errs = append(*errs, domain.FieldError{Field: field, Path: path, Message: msg})
+	}
+
+	if s.Nullable && value == nil {
+		return
+	}
+	if s.Not != nil && v.matches(s.Not, value) {
+		if isEmpty(s.Not) {
+			fail("is not allowed")
+		} else {
+			fail("must not match the schema")
+		}
+		return
+	}
+	for _, sub := range s.AllOf {
+		v.validate(sub, value, path, field, errs)
+	}
+	if len(s.AnyOf) > 0 && v.count(s.AnyOf, value) == 0 {
+		fail(v.alternatives(s.AnyOf))
+		return
+	}
+	if count := v.count(s.OneOf, value); len(s.OneOf) > 0 && count != 1 {
+		if count == 0 {
+			fail(v.alternatives(s.OneOf))
+		} else {
+			fail("must match exactly one schema")
+		}
+		return
+	}
+
+	if s.Type != "" && !isType(s.Type, value) {
+		fail(fmt.Sprintf("must be %s", article(s.Type)))
+		return
+	}
+	if len(s.Enum) > 0 && !inEnum(s.Enum, value) {
+		options := make([]string, len(s.Enum))
+		for n, option := range s.Enum {
+			options[n] = fmt.Sprint(option)
+		}
+		fail(fmt.Sprintf("must be one of %s", strings.Join(options, ", ")))
+		return
+	}
+
+	switch val := value.(type) {
+	case float64:
+		if s.Minimum != nil && val < *s.Minimum {
+			fail(fmt.Sprintf("must be at least %v", *s.Minimum))
+		}
+		if s.Maximum != nil && val > *s.Maximum {
+			fail(fmt.Sprintf("must be at most %v", *s.Maximum))
+		}
+	case string:
+		length := utf8.RuneCountInString(val)
+		if s.MinLength != nil && length < *s.MinLength {
+			fail(fmt.Sprintf("must be at least %d characters", *s.MinLength))
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			fail(fmt.Sprintf("must be at most %d characters", *s.MaxLength))
+		}
+		if s.Pattern != "" {
+			if re := pattern(s.Pattern); re != nil && !re.MatchString(val) {
+				fail(fmt.Sprintf("must match %s", s.Pattern))
+			}
+		}
+		if msg := checkFormat(s.Format, val); msg != "" {
+			fail(msg)
+		}
+	case []interface{}:
+		if s.MinItems != nil && len(val) < *s.MinItems {
+			fail(fmt.Sprintf("must contain at least %d items", *s.MinItems))
+		}
+		if s.MaxItems != nil && len(val) > *s.MaxItems {
+			fail(fmt.Sprintf("must contain at most %d items", *s.MaxItems))
+		}
+		if s.Items != nil {
+			for n, item := range val {
+				v.validate(s.Items, item, fmt.Sprintf("%s[%d]", path, n), field, errs)
+			}
+		}
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := val[name]; !ok {
+				*errs = append(*errs, domain.FieldError{Field: name, Path: join(path, name), Message: "is required"})
+			}
+		}
+		keys := make([]string, 0, len(val))
+		for key := range val {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if property, ok := s.Properties[key]; ok {
+				v.validate(property, val[key], join(path, key), key, errs)
+			} else if s.AdditionalProperties != nil {
+				v.validate(s.AdditionalProperties, val[key], join(path, key), key, errs)
+			}
+		}
+	}
+}
+
+// Reports whether value is valid against s
+func (v *Validator) matches(s *Schema, value interface{}) bool {
+	var errs []domain.FieldError
+	v.validate(s, value, "", "", &errs)
+	return len(errs) == 0
+}
+
+// Number of schemas value is valid against
+func (v *Validator) count(schemas []*Schema, value interface{}) int {
+	count := 0
+	for _, s := range schemas {
+		if v.matches(s, value) {
+			count++
+		}
+	}
+	return count
+}
+
+// Message for values matching none of anyOf or oneOf, listing types when every schema
+// is a plain type, e.g. must be a string or null
+func (v *Validator) alternatives(schemas []*Schema) string {
+	types := make([]string, len(schemas))
+	for n, s := range schemas {
+		s = v.resolve(s)
+		if s.Type == "" || !reflect.DeepEqual(*s, Schema{Type: s.Type}) {
+			return "must match one of the schemas"
+		}
+		types[n] = article(s.Type)
+	}
+	return "must be " + strings.Join(types, " or ")
+}
+
+// Schema accepting every value, as negated by schemas decoded from false
+func isEmpty(s *Schema) bool {
+	return reflect.DeepEqual(*s, Schema{})
+}
+
+func isType(schemaType string, value interface{}) bool {
+	switch schemaType {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		f, ok := value.(float64)
+		return ok && f == math.Trunc(f)
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "null":
+		return value == nil
+	}
+	return true
+}
+
+// Compares enum options by value, numbers regardless of their Go type
+func inEnum(options []interface{}, value interface{}) bool {
+	for _, option := range options {
+		if a, ok := number(option); ok {
+			if b, ok := number(value); ok && a == b {
+				return true
+			}
+			continue
+		}
+		if reflect.DeepEqual(option, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func number(v interface{}) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+func checkFormat(format string, value string) string {
+	switch format {
+	case "email":
+		if addr, err := mail.ParseAddress(value); err != nil || addr.Address != value {
+			return "must be a valid email address"
+		}
+	case "uuid":
+		if !uuidPattern.MatchString(value) {
+			return "must be a valid UUID"
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return "must be an RFC 3339 date-time"
+		}
+	case "date":
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return "must be a date formatted as YYYY-MM-DD"
+		}
+	}
+	return ""
+}
+
+// Compiled pattern, cached per expression. Invalid patterns are not checked
+func pattern(expr string) *regexp.Regexp {
+	if re, ok := patterns.Load(expr); ok {
+		return re.(*regexp.Regexp)
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil
+	}
+	patterns.Store(expr, re)
+	return re
+}
+
+func article(schemaType string) string {
+	switch schemaType {
+	case "integer", "object", "array":
+		return "an " + schemaType
+	case "null":
+		return schemaType
+	}
+	return "a " + schemaType
+}
+
+func join(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
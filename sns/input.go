@@ -1,31 +1,70 @@
 package sns
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"strings"
 
-	"github.com/matthisstenius/logger"
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/logging"
 )
 
 // Input for parsed SNS event
 // TODO: Write tests
 type Input struct {
-	event map[string]interface{}
+	event  map[string]interface{}
+	logger domain.Logger
+	ctx    context.Context
 }
 
 // NewInput initializer
 func NewInput(e map[string]interface{}) *Input {
-	return &Input{event: e}
+	return &Input{event: e, logger: logging.Default(), ctx: context.Background()}
+}
+
+// Context for current invocation
+func (i *Input) Context() context.Context {
+	return i.ctx
+}
+
+// Logger for current event
+func (i *Input) Logger() domain.Logger {
+	return i.logger
 }
 
 // ParseMessage as JSON
 func (i *Input) ParseMessage(out interface{}) error {
 	record := i.event["Records"].([]interface{})[0].(map[string]interface{})
 	if err := json.Unmarshal([]byte(record["Sns"].(map[string]interface{})["Message"].(string)), out); err != nil {
-		logger.WithFields(logger.Fields{
+		i.logger.Error("SNSInput::ParseMessage() could not unmarshal json", domain.Fields{
 			"error": err,
-		}).Error("SNSInput::ParseMessage() could not unmarshal json")
+		})
 		return errors.New("invalid SNS payload")
 	}
 	return nil
 }
+
+// CorrelationID from X-Correlation-Id message attribute, falls back to SNS message id
+func (i *Input) CorrelationID() string {
+	records, ok := i.event["Records"].([]interface{})
+	if !ok || len(records) == 0 {
+		return ""
+	}
+	record, _ := records[0].(map[string]interface{})
+	message, ok := record["Sns"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	if attributes, ok := message["MessageAttributes"].(map[string]interface{}); ok {
+		for k, v := range attributes {
+			attribute, _ := v.(map[string]interface{})
+			if value, ok := attribute["Value"].(string); ok && value != "" && strings.EqualFold(k, domain.CorrelationIDHeader) {
+				return value
+			}
+		}
+	}
+	id, _ := message["MessageId"].(string)
+	return id
+}
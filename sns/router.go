@@ -1,8 +1,14 @@
 package sns
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/logging"
 )
 
 const EventSource = "aws:sns"
@@ -10,6 +16,8 @@ const EventSource = "aws:sns"
 // Route mapping for handler
 type Route struct {
 	Handler func(i *Input) domain.Response
+	// Message value documenting the decoded message type, set by Typed
+	Message interface{}
 }
 
 // Routes mappings for SNS handlers
@@ -17,22 +25,68 @@ type Routes map[string]Route
 
 // Router for SNS events
 type Router struct {
-	routes Routes
+	routes       Routes
+	logging      domain.Logging
+	logger       domain.Logger
+	customLogger bool
+}
+
+// Option for configuring Router
+type Option func(r *Router)
+
+// WithLogging levels for incoming events and outgoing responses
+func WithLogging(logging domain.Logging) Option {
+	return func(r *Router) {
+		r.logging = logging
+	}
+}
+
+// WithLogger for routing and handler logging, defaults to logging.Default()
+func WithLogger(logger domain.Logger) Option {
+	return func(r *Router) {
+		r.logger = logger
+		r.customLogger = true
+	}
+}
+
+// DefaultLogger replaces logging.Default() unless a logger was set with WithLogger, used
+// by router.Event for Config.Logger
+func (r *Router) DefaultLogger(logger domain.Logger) {
+	if !r.customLogger {
+		r.logger = logger
+	}
 }
 
 // NewRouter initializer
-func NewRouter(routes Routes) *Router {
-	return &Router{routes: routes}
+func NewRouter(routes Routes, opts ...Option) *Router {
+	r := &Router{routes: routes, logger: logging.Default()}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 // Route incoming event to corresponding handler
 func (r *Router) Route(evt map[string]interface{}) (domain.Response, error) {
-	record := evt["Records"].([]interface{})[0].(map[string]interface{})
-	route, ok := r.routes[record["Sns"].(map[string]interface{})["TopicArn"].(string)]
+	return r.RouteContext(context.Background(), evt)
+}
+
+// RouteContext routes incoming event to corresponding handler with invocation context.
+// Handlers responding NewErrorResponse make the router return their error
+func (r *Router) RouteContext(ctx context.Context, evt map[string]interface{}) (domain.Response, error) {
+	route, ok := r.routes[r.topicARN(evt)]
 	if !ok {
 		return nil, errors.New("handler func missing")
 	}
-	return route.Handler(NewInput(evt)), nil
+	i := NewInput(evt)
+	i.ctx = ctx
+	i.logger = r.logger.With(domain.Fields{domain.CorrelationIDField: i.CorrelationID()})
+	res := route.Handler(i)
+	r.logResponse(i.logger, res)
+	if res, ok := res.(*Response); ok && res.err != nil {
+		return nil, res.err
+	}
+	return res, nil
 }
 
 // IsMatch for SNS event
@@ -42,3 +96,73 @@ func (r *Router) IsMatch(e map[string]interface{}) bool {
 	}
 	return false
 }
+
+// Logging levels for SNS events
+func (r *Router) Logging() domain.Logging {
+	return r.logging
+}
+
+// RouteKey topic name, falls back to topic ARN
+func (r *Router) RouteKey(evt map[string]interface{}) string {
+	fragments := strings.Split(r.topicARN(evt), ":")
+	return fragments[len(fragments)-1]
+}
+
+func (r *Router) topicARN(evt map[string]interface{}) string {
+	record := evt["Records"].([]interface{})[0].(map[string]interface{})
+	return record["Sns"].(map[string]interface{})["TopicArn"].(string)
+}
+
+// CorrelationID for SNS event
+func (r *Router) CorrelationID(evt map[string]interface{}) string {
+	return NewInput(evt).CorrelationID()
+}
+
+func (r *Router) logResponse(logger domain.Logger, res domain.Response) {
+	response, ok := res.(*Response)
+	if !ok {
+		return
+	}
+	if response.err != nil {
+		logger.Error("Router::Route() SNS handler failed", domain.Fields{"error": response.err.Error()})
+		return
+	}
+	logger.Info("Router::Route() SNS handler responded", domain.Fields{"message": response.message})
+}
+
+// Routes registered in router sorted by topic ARN
+func (r *Router) Routes() []domain.RouteInfo {
+	routes := make([]domain.RouteInfo, 0, len(r.routes))
+	for _, key := range r.keys() {
+		route := r.routes[key]
+		routes = append(routes, domain.RouteInfo{
+			Source:  domain.SourceSNS,
+			Key:     key,
+			Payload: route.Message,
+		})
+	}
+	return routes
+}
+
+// Validate route table, reporting empty keys and missing handlers
+func (r *Router) Validate() error {
+	var errs []error
+	for _, key := range r.keys() {
+		if key == "" {
+			errs = append(errs, errors.New("route with empty topic ARN"))
+		}
+		if r.routes[key].Handler == nil {
+			errs = append(errs, fmt.Errorf("route %s: handler missing", key))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Router) keys() []string {
+	keys := make([]string, 0, len(r.routes))
+	for key := range r.routes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
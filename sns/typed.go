@@ -0,0 +1,27 @@
+package sns
+
+import (
+	"context"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+)
+
+// Typed Route decoding the JSON message once before calling fn. Messages that can not be
+// decoded are logged and acknowledged as retrying would not help, errors returned by fn
+// fail the invocation so that Lambda retries the event
+func Typed[Msg any](fn func(ctx context.Context, msg Msg) error) Route {
+	var msg Msg
+	return Route{
+		Message: msg,
+		Handler: func(i *Input) domain.Response {
+			var msg Msg
+			if err := i.ParseMessage(&msg); err != nil {
+				return NewResponse("invalid message discarded")
+			}
+			if err := fn(i.Context(), msg); err != nil {
+				return NewErrorResponse(err)
+			}
+			return NewResponse("message handled")
+		},
+	}
+}
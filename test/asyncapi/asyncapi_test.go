@@ -0,0 +1,99 @@
+package asyncapi
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	router "github.com/matthisstenius/lambda-router/v4"
+	"github.com/matthisstenius/lambda-router/v4/asyncapi"
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/dynamodb"
+	"github.com/matthisstenius/lambda-router/v4/http"
+	"github.com/matthisstenius/lambda-router/v4/s3"
+	"github.com/matthisstenius/lambda-router/v4/schedule"
+	"github.com/matthisstenius/lambda-router/v4/sns"
+	"github.com/stretchr/testify/assert"
+	internalHTTP "net/http"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+type orderCreated struct {
+	ID    string  `json:"id" validate:"required"`
+	Total float64 `json:"total" validate:"min=0"`
+}
+
+type order struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+func event() *router.Event {
+	return router.NewEvent(&router.Config{
+		HTTP: http.NewRouter(http.Routes{
+			"/orders": {internalHTTP.MethodGet: http.Route{Handler: func(i *http.Input) domain.Response { return nil }}},
+		}, nil),
+		SNS: sns.NewRouter(sns.Routes{
+			"arn:aws:sns:eu-west-1:123456789012:orders-created": sns.Typed(func(ctx context.Context, msg orderCreated) error {
+				return nil
+			}),
+			"arn:aws:sns:eu-west-1:123456789012:notifications": sns.Route{
+				Handler: func(i *sns.Input) domain.Response { return nil },
+			},
+		}),
+		DynamoDB: dynamodb.NewRouter(dynamodb.Routes{
+			"arn:aws:dynamodb:eu-west-1:123456789012:table/Orders/stream/2020-01-01T00:00:00.000": dynamodb.Typed(func(ctx context.Context, change dynamodb.Change[order]) error {
+				return nil
+			}),
+		}),
+		S3: s3.NewRouter(s3.Routes{
+			"/uploads/invoices": s3.Route{Handler: func(i *s3.Input) domain.Response { return nil }},
+		}),
+		Scheduled: schedule.NewRouter(schedule.Routes{
+			"arn:aws:events:eu-west-1:123456789012:rule/nightly-report": schedule.Route{Handler: func() domain.Response { return nil }},
+		}),
+	})
+}
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Golden string
+		Encode func(doc *asyncapi.Document) ([]byte, error)
+	}{
+		{
+			Name:   "it should generate JSON",
+			Golden: "asyncapi.json",
+			Encode: (*asyncapi.Document).JSON,
+		},
+		{
+			Name:   "it should generate YAML",
+			Golden: "asyncapi.yaml",
+			Encode: (*asyncapi.Document).YAML,
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			config := asyncapi.Config{Info: asyncapi.Info{Title: "Orders", Version: "1.0.0"}}
+
+			// When
+			doc := asyncapi.Generate(event().Routes(), config)
+			out, err := td.Encode(doc)
+
+			// Then
+			assert.Nil(t, err)
+			golden := filepath.Join("testdata", td.Golden)
+			if *update {
+				assert.Nil(t, ioutil.WriteFile(golden, out, 0644))
+			}
+			expected, err := ioutil.ReadFile(golden)
+			assert.Nil(t, err)
+			assert.Equal(t, string(expected), string(out))
+		})
+	}
+}
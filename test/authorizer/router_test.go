@@ -0,0 +1,172 @@
+package authorizer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matthisstenius/lambda-router/v4/authorizer"
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+const methodARN = "arn:aws:execute-api:eu-west-1:123456789012:abcdef123/prod/GET/users/1"
+
+func TestRoute(t *testing.T) {
+	tests := []struct {
+		Name    string
+		Event   map[string]interface{}
+		Handler func(i *authorizer.Input) domain.Response
+		Payload interface{}
+		Error   error
+	}{
+		{
+			Name: "it should succeed with token authorizer policy",
+			Event: map[string]interface{}{
+				"type":               authorizer.TypeToken,
+				"authorizationToken": "Bearer secret",
+				"methodArn":          methodARN,
+			},
+			Handler: func(i *authorizer.Input) domain.Response {
+				arn, _ := i.ParsedMethodARN()
+				return authorizer.NewPolicy(i.Token()).
+					Allow(arn.With("GET", "users/*")).
+					Deny(arn.With("*", "admin/*")).
+					WithContext("tenantId", "acme")
+			},
+			Payload: map[string]interface{}{
+				"principalId": "secret",
+				"policyDocument": map[string]interface{}{
+					"Version": "2012-10-17",
+					"Statement": []interface{}{
+						map[string]interface{}{
+							"Action":   "execute-api:Invoke",
+							"Effect":   authorizer.EffectAllow,
+							"Resource": []string{"arn:aws:execute-api:eu-west-1:123456789012:abcdef123/prod/GET/users/*"},
+						},
+						map[string]interface{}{
+							"Action":   "execute-api:Invoke",
+							"Effect":   authorizer.EffectDeny,
+							"Resource": []string{"arn:aws:execute-api:eu-west-1:123456789012:abcdef123/prod/*/admin/*"},
+						},
+					},
+				},
+				"context": map[string]interface{}{"tenantId": "acme"},
+			},
+		},
+		{
+			Name: "it should succeed with simple response",
+			Event: map[string]interface{}{
+				"version":        "2.0",
+				"type":           authorizer.TypeRequest,
+				"routeArn":       methodARN,
+				"identitySource": []interface{}{"secret"},
+				"headers":        map[string]interface{}{"x-tenant": "acme"},
+			},
+			Handler: func(i *authorizer.Input) domain.Response {
+				return authorizer.NewSimpleResponse(i.Token() == "secret", map[string]interface{}{
+					"tenantId": i.GetHeader("X-Tenant"),
+				})
+			},
+			Payload: map[string]interface{}{
+				"isAuthorized": true,
+				"context":      map[string]interface{}{"tenantId": "acme"},
+			},
+		},
+		{
+			Name: "it should handle unauthorized",
+			Event: map[string]interface{}{
+				"type":               authorizer.TypeToken,
+				"authorizationToken": "invalid",
+				"methodArn":          methodARN,
+			},
+			Handler: func(i *authorizer.Input) domain.Response {
+				return authorizer.Unauthorized()
+			},
+			Error: authorizer.ErrUnauthorized,
+		},
+		{
+			Name: "it should handle missing handler",
+			Event: map[string]interface{}{
+				"type":      authorizer.TypeRequest,
+				"methodArn": methodARN,
+			},
+			Error: errors.New("handler func missing"),
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			routes := authorizer.Routes{}
+			if td.Handler != nil {
+				routes[td.Event["type"].(string)] = authorizer.Route{Handler: td.Handler}
+			}
+
+			// When
+			router := authorizer.NewRouter(routes)
+			res, err := router.Route(td.Event)
+
+			// Then
+			assert.Equal(t, td.Error, err)
+			if td.Error == nil {
+				assert.Equal(t, td.Payload, res.Payload())
+			}
+		})
+	}
+}
+
+func TestIsMatch(t *testing.T) {
+	tests := []struct {
+		Name    string
+		Event   map[string]interface{}
+		IsMatch bool
+	}{
+		{
+			Name:    "it should match token event",
+			Event:   map[string]interface{}{"type": authorizer.TypeToken, "methodArn": methodARN},
+			IsMatch: true,
+		},
+		{
+			Name:    "it should match HTTP API request event",
+			Event:   map[string]interface{}{"type": authorizer.TypeRequest, "routeArn": methodARN},
+			IsMatch: true,
+		},
+		{
+			Name:    "it should none match",
+			Event:   map[string]interface{}{"httpMethod": "GET"},
+			IsMatch: false,
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// When
+			router := authorizer.NewRouter(authorizer.Routes{})
+			isMatch := router.IsMatch(td.Event)
+
+			// Then
+			assert.Equal(t, td.IsMatch, isMatch)
+		})
+	}
+}
+
+func TestParseMethodARN(t *testing.T) {
+	// When
+	arn, err := authorizer.ParseMethodARN(methodARN)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, &authorizer.MethodARN{
+		Partition: "aws",
+		Region:    "eu-west-1",
+		AccountID: "123456789012",
+		APIID:     "abcdef123",
+		Stage:     "prod",
+		Method:    "GET",
+		Resource:  "users/1",
+	}, arn)
+	assert.Equal(t, methodARN, arn.String())
+
+	_, err = authorizer.ParseMethodARN("invalid")
+	assert.NotNil(t, err)
+}
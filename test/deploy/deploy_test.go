@@ -0,0 +1,153 @@
+package deploy
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matthisstenius/lambda-router/v4/deploy"
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/stretchr/testify/assert"
+	internalHTTP "net/http"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+const (
+	topic  = "arn:aws:sns:eu-west-1:123456789012:orders-created"
+	stream = "arn:aws:dynamodb:eu-west-1:123456789012:table/Orders/stream/2020-01-01T00:00:00.000"
+	rule   = "arn:aws:events:eu-west-1:123456789012:rule/nightly-report"
+)
+
+var routes = []domain.RouteInfo{
+	{Source: domain.SourceAuthorizer, Key: "TOKEN"},
+	{Source: domain.SourceHTTP, Key: "/orders", Method: internalHTTP.MethodGet},
+	{Source: domain.SourceHTTP, Key: "/orders/{id}", Method: internalHTTP.MethodPut},
+	{Source: domain.SourceSchedule, Key: rule},
+	{Source: domain.SourceDynamoDB, Key: stream},
+	{Source: domain.SourceS3, Key: "/uploads/invoices"},
+	{Source: domain.SourceSNS, Key: topic},
+}
+
+var config = deploy.Config{
+	Bucket:    "Uploads",
+	Schedules: map[string]string{rule: "cron(0 2 * * ? *)"},
+}
+
+type encoder interface {
+	YAML() ([]byte, error)
+}
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Golden   string
+		Generate func(routes []domain.RouteInfo, config deploy.Config) (encoder, error)
+	}{
+		{
+			Name:   "it should generate SAM events",
+			Golden: "sam.yaml",
+			Generate: func(routes []domain.RouteInfo, config deploy.Config) (encoder, error) {
+				return deploy.SAM(routes, config)
+			},
+		},
+		{
+			Name:   "it should generate Serverless events",
+			Golden: "serverless.yaml",
+			Generate: func(routes []domain.RouteInfo, config deploy.Config) (encoder, error) {
+				return deploy.Serverless(routes, config)
+			},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// When
+			events, err := td.Generate(routes, config)
+			assert.Nil(t, err)
+			out, err := events.YAML()
+
+			// Then
+			assert.Nil(t, err)
+			golden := filepath.Join("testdata", td.Golden)
+			if *update {
+				assert.Nil(t, os.WriteFile(golden, out, 0644))
+			}
+			expected, err := os.ReadFile(golden)
+			assert.Nil(t, err)
+			assert.Equal(t, string(expected), string(out))
+		})
+	}
+}
+
+func TestGenerateMissingConfig(t *testing.T) {
+	// When
+	_, samErr := deploy.SAM(routes, deploy.Config{})
+	_, serverlessErr := deploy.Serverless(routes, deploy.Config{})
+
+	// Then
+	expected := "schedule " + rule + ": schedule expression missing\ns3 /uploads/invoices: bucket missing"
+	assert.EqualError(t, samErr, expected)
+	assert.EqualError(t, serverlessErr, expected)
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Template string
+		Config   deploy.Config
+		Missing  []string
+		Extra    []string
+		Err      bool
+	}{
+		{
+			Name:     "it should match SAM events",
+			Template: "template.yaml",
+			Config:   deploy.Config{Function: "Api"},
+		},
+		{
+			Name:     "it should report SAM mismatches",
+			Template: "template.yaml",
+			Config:   deploy.Config{Function: "Reports"},
+			Missing: []string{
+				"dynamodb " + stream,
+				"http GET /orders",
+				"http PUT /orders/{id}",
+				"s3 /uploads/invoices",
+				"schedule nightly-report",
+				"sns " + topic,
+			},
+			Extra: []string{"http DELETE /reports/{id}", "sns ReportsTopic.TopicArn"},
+		},
+		{
+			Name:     "it should report Serverless mismatches",
+			Template: "serverless.yml",
+			Missing:  []string{"dynamodb " + stream, "schedule nightly-report"},
+			Extra:    []string{"http POST /orders", "schedule legacy-report"},
+		},
+		{
+			Name:     "it should fail for unknown templates",
+			Template: "sam.yaml",
+			Err:      true,
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// When
+			diff, err := deploy.CheckFile(routes, td.Config, filepath.Join("testdata", td.Template))
+
+			// Then
+			if td.Err {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, td.Missing, diff.Missing)
+			assert.Equal(t, td.Extra, diff.Extra)
+			assert.Equal(t, td.Missing == nil && td.Extra == nil, diff.Empty())
+			assert.Equal(t, diff.Empty(), diff.Error() == nil)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllows(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Claims map[string]interface{}
+		Roles  []string
+		Match  domain.MatchMode
+		Out    bool
+	}{
+		{
+			Name:   "it should succeed with single value",
+			Claims: map[string]interface{}{"cognito:groups": "Admin"},
+			Roles:  []string{"Admin"},
+			Out:    true,
+		},
+		{
+			Name:   "it should succeed with whole value containing space",
+			Claims: map[string]interface{}{"cognito:groups": "Super Admin"},
+			Roles:  []string{"Super Admin"},
+			Out:    true,
+		},
+		{
+			Name:   "it should succeed with array claim",
+			Claims: map[string]interface{}{"cognito:groups": []interface{}{"Users", "Admin"}},
+			Roles:  []string{"Admin"},
+			Out:    true,
+		},
+		{
+			Name:   "it should succeed with comma separated claim",
+			Claims: map[string]interface{}{"cognito:groups": "Users,Admin"},
+			Roles:  []string{"Admin"},
+			Out:    true,
+		},
+		{
+			Name:   "it should succeed with bracketed space separated claim",
+			Claims: map[string]interface{}{"cognito:groups": "[Users Admin]"},
+			Roles:  []string{"Admin"},
+			Out:    true,
+		},
+		{
+			Name:   "it should succeed with JSON encoded array claim",
+			Claims: map[string]interface{}{"cognito:groups": `["Users","Admin"]`},
+			Roles:  []string{"Admin"},
+			Out:    true,
+		},
+		{
+			Name:   "it should succeed when all roles match",
+			Claims: map[string]interface{}{"cognito:groups": []interface{}{"Users", "Admin"}},
+			Roles:  []string{"Admin", "Users"},
+			Match:  domain.MatchAll,
+			Out:    true,
+		},
+		{
+			Name:   "it should handle partial match with all of roles",
+			Claims: map[string]interface{}{"cognito:groups": []interface{}{"Users"}},
+			Roles:  []string{"Admin", "Users"},
+			Match:  domain.MatchAll,
+			Out:    false,
+		},
+		{
+			Name:   "it should handle roles mismatch",
+			Claims: map[string]interface{}{"cognito:groups": "Users Other"},
+			Roles:  []string{"Admin"},
+			Out:    false,
+		},
+		{
+			Name:   "it should handle missing claim",
+			Claims: map[string]interface{}{},
+			Roles:  []string{"Admin"},
+			Out:    false,
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			access := &domain.Access{Roles: td.Roles, Key: "cognito:groups", Match: td.Match}
+
+			// When
+			out := access.Allows(domain.NewAuthClaims(td.Claims))
+
+			// Then
+			assert.Equal(t, td.Out, out)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorize(t *testing.T) {
+	admin := &domain.Access{Roles: []string{"Admin"}, Key: "cognito:groups"}
+	tests := []struct {
+		Name       string
+		Policy     domain.Policy
+		Claims     map[string]interface{}
+		PathParams map[string]string
+		Out        bool
+	}{
+		{
+			Name:   "it should succeed with scopes",
+			Policy: domain.Scopes("orders/read", "orders/write"),
+			Claims: map[string]interface{}{"scope": "openid orders/read orders/write"},
+			Out:    true,
+		},
+		{
+			Name:   "it should handle missing scope",
+			Policy: domain.Scopes("orders/read", "orders/write"),
+			Claims: map[string]interface{}{"scope": "openid orders/read"},
+			Out:    false,
+		},
+		{
+			Name:       "it should succeed with tenant claim matching path param",
+			Policy:     domain.ClaimMatchesParam("custom:tenantId", "tenantId"),
+			Claims:     map[string]interface{}{"custom:tenantId": "acme"},
+			PathParams: map[string]string{"tenantId": "acme"},
+			Out:        true,
+		},
+		{
+			Name:       "it should handle tenant mismatch",
+			Policy:     domain.ClaimMatchesParam("custom:tenantId", "tenantId"),
+			Claims:     map[string]interface{}{"custom:tenantId": "acme"},
+			PathParams: map[string]string{"tenantId": "other"},
+			Out:        false,
+		},
+		{
+			Name:       "it should succeed with resource owner",
+			Policy:     domain.Owner("userId"),
+			Claims:     map[string]interface{}{"sub": "123"},
+			PathParams: map[string]string{"userId": "123"},
+			Out:        true,
+		},
+		{
+			Name:       "it should succeed with owner or admin",
+			Policy:     domain.Any(domain.Owner("userId"), admin),
+			Claims:     map[string]interface{}{"sub": "456", "cognito:groups": "Admin"},
+			PathParams: map[string]string{"userId": "123"},
+			Out:        true,
+		},
+		{
+			Name:   "it should handle all of with one denial",
+			Policy: domain.All(admin, domain.Scopes("orders/read")),
+			Claims: map[string]interface{}{"cognito:groups": "Admin"},
+			Out:    false,
+		},
+		{
+			Name:   "it should negate policy",
+			Policy: domain.Not(admin),
+			Claims: map[string]interface{}{"cognito:groups": "Users"},
+			Out:    true,
+		},
+		{
+			Name: "it should succeed with custom predicate",
+			Policy: domain.PolicyFunc(func(req *domain.PolicyRequest) bool {
+				return req.Claims.Get("email_verified") == true
+			}),
+			Claims: map[string]interface{}{"email_verified": true},
+			Out:    true,
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			req := &domain.PolicyRequest{Claims: domain.NewAuthClaims(td.Claims), PathParams: td.PathParams}
+
+			// When
+			out := td.Policy.Authorize(req)
+
+			// Then
+			assert.Equal(t, td.Out, out)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/matthisstenius/lambda-router/v4/dynamodb"
+	"github.com/matthisstenius/lambda-router/v4/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+type order struct {
+	ID    string `json:"id"`
+	Total int    `json:"total"`
+}
+
+func TestTyped(t *testing.T) {
+	tests := []struct {
+		Name         string
+		EventName    string
+		Images       map[string]interface{}
+		HandlerError error
+		Out          *dynamodb.Change[order]
+		Error        error
+	}{
+		{
+			Name:      "it should succeed",
+			EventName: "MODIFY",
+			Images: map[string]interface{}{
+				"OldImage": map[string]interface{}{
+					"id":    map[string]interface{}{"S": "1"},
+					"total": map[string]interface{}{"N": "10"},
+				},
+				"NewImage": map[string]interface{}{
+					"id":    map[string]interface{}{"S": "1"},
+					"total": map[string]interface{}{"N": "20"},
+				},
+			},
+			Out: &dynamodb.Change[order]{
+				Type: dynamodb.EventModify,
+				Old:  &order{ID: "1", Total: 10},
+				New:  &order{ID: "1", Total: 20},
+			},
+		},
+		{
+			Name:      "it should leave missing image nil",
+			EventName: "INSERT",
+			Images: map[string]interface{}{
+				"NewImage": map[string]interface{}{
+					"id": map[string]interface{}{"S": "1"},
+				},
+			},
+			Out: &dynamodb.Change[order]{
+				Type: dynamodb.EventInsert,
+				New:  &order{ID: "1"},
+			},
+		},
+		{
+			Name:      "it should discard invalid record",
+			EventName: "INSERT",
+			Images: map[string]interface{}{
+				"NewImage": map[string]interface{}{
+					"id": map[string]interface{}{"N": "1"},
+				},
+			},
+		},
+		{
+			Name:      "it should fail invocation on handler error",
+			EventName: "REMOVE",
+			Images: map[string]interface{}{
+				"OldImage": map[string]interface{}{
+					"id": map[string]interface{}{"S": "1"},
+				},
+			},
+			HandlerError: errors.New("downstream unavailable"),
+			Out: &dynamodb.Change[order]{
+				Type: dynamodb.EventRemove,
+				Old:  &order{ID: "1"},
+			},
+			Error: errors.New("downstream unavailable"),
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			var out *dynamodb.Change[order]
+			routes := dynamodb.Routes{
+				"test-stream": dynamodb.Typed(func(ctx context.Context, change dynamodb.Change[order]) error {
+					out = &change
+					return td.HandlerError
+				}),
+			}
+			event := map[string]interface{}{
+				"Records": []interface{}{
+					map[string]interface{}{
+						"eventSourceARN": "test-stream",
+						"eventName":      td.EventName,
+						"dynamodb":       td.Images,
+					},
+				},
+			}
+
+			// When
+			router := dynamodb.NewRouter(routes, dynamodb.WithLogger(logging.Nop()))
+			_, err := router.Route(event)
+
+			// Then
+			assert.Equal(t, td.Error, err)
+			assert.Equal(t, td.Out, out)
+		})
+	}
+}
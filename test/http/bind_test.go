@@ -0,0 +1,234 @@
+package http
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/http"
+	"github.com/stretchr/testify/assert"
+	internalHTTP "net/http"
+)
+
+type level int
+
+func (l *level) UnmarshalText(text []byte) error {
+	switch strings.ToLower(string(text)) {
+	case "low":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return errors.New("unknown level")
+	}
+	return nil
+}
+
+type bindRequest struct {
+	ID      int           `path:"id"`
+	Limit   int           `query:"limit" default:"10" validate:"max=100"`
+	Active  *bool         `query:"active"`
+	Tags    []string      `query:"tag"`
+	Since   time.Time     `query:"since"`
+	Timeout time.Duration `query:"timeout"`
+	Level   level         `query:"level"`
+	Tenant  string        `header:"X-Tenant"`
+	Token   []byte        `header:"X-Token"`
+	Name    string        `json:"name"`
+}
+
+func TestBind(t *testing.T) {
+	active := true
+	tests := []struct {
+		Name  string
+		Event map[string]interface{}
+		Out   bindRequest
+		Error error
+	}{
+		{
+			Name: "it should succeed",
+			Event: map[string]interface{}{
+				"pathParameters": map[string]interface{}{"id": "42"},
+				"multiValueQueryStringParameters": map[string]interface{}{
+					"tag":     []interface{}{"a", "b"},
+					"active":  []interface{}{"true"},
+					"since":   []interface{}{"2023-01-02"},
+					"timeout": []interface{}{"5s"},
+					"level":   []interface{}{"High"},
+				},
+				"headers": map[string]interface{}{"x-tenant": "acme"},
+				"body":    `{"name": "test"}`,
+			},
+			Out: bindRequest{
+				ID:      42,
+				Limit:   10,
+				Active:  &active,
+				Tags:    []string{"a", "b"},
+				Since:   time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+				Timeout: 5 * time.Second,
+				Level:   2,
+				Tenant:  "acme",
+				Name:    "test",
+			},
+		},
+		{
+			Name: "it should split comma separated slice values",
+			Event: map[string]interface{}{
+				"queryStringParameters": map[string]interface{}{"tag": "a,b", "limit": "5"},
+			},
+			Out: bindRequest{Limit: 5, Tags: []string{"a", "b"}},
+		},
+		{
+			Name: "it should not split byte slice values",
+			Event: map[string]interface{}{
+				"headers": map[string]interface{}{"X-Token": "a,b"},
+			},
+			Out: bindRequest{Limit: 10, Token: []byte("a,b")},
+		},
+		{
+			Name: "it should not bind param fields from body",
+			Event: map[string]interface{}{
+				"body": `{"ID": 7, "Limit": 50, "Tenant": "other", "Token": "YQ==", "name": "test"}`,
+			},
+			Out: bindRequest{Limit: 10, Name: "test"},
+		},
+		{
+			Name: "it should handle invalid values",
+			Event: map[string]interface{}{
+				"pathParameters":        map[string]interface{}{"id": "abc"},
+				"queryStringParameters": map[string]interface{}{"level": "unknown"},
+				"body":                  `{"name": 1}`,
+			},
+			Error: &http.BindError{
+				Message: "invalid request",
+				Fields: []domain.FieldError{
+					{Field: "name", Source: http.SourceBody, Path: "name", Message: "must be a string"},
+					{Field: "id", Source: http.SourcePath, Path: "id", Message: "must be an integer"},
+					{Field: "level", Source: http.SourceQuery, Path: "level", Message: "invalid value: unknown level"},
+				},
+			},
+		},
+		{
+			Name:  "it should handle invalid body",
+			Event: map[string]interface{}{"body": `{"name": invalid}`},
+			Error: &http.BindError{
+				Message: "invalid request",
+				Fields: []domain.FieldError{
+					{Source: http.SourceBody, Message: "could not parse body as JSON"},
+				},
+			},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			input := http.NewInput(td.Event)
+
+			// When
+			var out bindRequest
+			err := input.Bind(&out)
+
+			// Then
+			assert.Equal(t, td.Error, err)
+			if td.Error == nil {
+				assert.Equal(t, td.Out, out)
+			}
+		})
+	}
+}
+
+func TestRouteRequest(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Event      map[string]interface{}
+		StatusCode int
+	}{
+		{
+			Name: "it should succeed",
+			Event: map[string]interface{}{
+				"pathParameters": map[string]interface{}{"id": "42"},
+			},
+			StatusCode: internalHTTP.StatusOK,
+		},
+		{
+			Name: "it should respond bad request on bind failure",
+			Event: map[string]interface{}{
+				"pathParameters": map[string]interface{}{"id": "abc"},
+			},
+			StatusCode: internalHTTP.StatusBadRequest,
+		},
+		{
+			Name: "it should respond unprocessable entity on validation failure",
+			Event: map[string]interface{}{
+				"pathParameters":        map[string]interface{}{"id": "42"},
+				"queryStringParameters": map[string]interface{}{"limit": "500"},
+			},
+			StatusCode: internalHTTP.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			routes := http.Routes{
+				"/test/{id}": {
+					internalHTTP.MethodGet: http.Route{
+						Request: bindRequest{},
+						Handler: func(i *http.Input) domain.Response {
+							req := i.Request().(*bindRequest)
+							return http.NewResponse(internalHTTP.StatusOK, req.ID)
+						},
+					},
+				},
+			}
+			td.Event["resource"] = "/test/{id}"
+			td.Event["httpMethod"] = internalHTTP.MethodGet
+
+			// When
+			router := http.NewRouter(routes, nil)
+			res, err := router.Route(td.Event)
+
+			// Then
+			assert.Nil(t, err)
+			assert.Equal(t, td.StatusCode, res.Payload().(map[string]interface{})["statusCode"])
+		})
+	}
+}
+
+type unsupportedRequest struct {
+	Filter map[string]string `query:"filter"`
+}
+
+func TestBindUnsupportedType(t *testing.T) {
+	// Given
+	event := map[string]interface{}{
+		"resource":              "/test",
+		"httpMethod":            internalHTTP.MethodGet,
+		"queryStringParameters": map[string]interface{}{"filter": "a"},
+	}
+	routes := http.Routes{
+		"/test": {
+			internalHTTP.MethodGet: http.Route{
+				Request: unsupportedRequest{},
+				Handler: func(i *http.Input) domain.Response {
+					return http.NewResponse(internalHTTP.StatusOK, nil)
+				},
+			},
+		},
+	}
+
+	// When
+	var out unsupportedRequest
+	err := http.NewInput(event).Bind(&out)
+	router := http.NewRouter(routes, nil)
+	res, routeErr := router.Route(event)
+
+	// Then
+	assert.EqualError(t, err, "field Filter: unsupported query field type map[string]string")
+	assert.Nil(t, routeErr)
+	assert.Equal(t, internalHTTP.StatusInternalServerError, res.Payload().(map[string]interface{})["statusCode"])
+	assert.EqualError(t, router.Validate(), "route GET /test: field Filter: unsupported query field type map[string]string")
+}
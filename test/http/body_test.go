@@ -0,0 +1,244 @@
+package http
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"testing"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/http"
+	"github.com/stretchr/testify/assert"
+	internalHTTP "net/http"
+)
+
+// Builds a base64 encoded multipart body and its Content-Type header
+func multipartBody(t *testing.T) (string, string) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	assert.Nil(t, writer.WriteField("name", "report"))
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="file"; filename="report.csv"`)
+	header.Set("Content-Type", "text/csv")
+	part, err := writer.CreatePart(header)
+	assert.Nil(t, err)
+	_, err = part.Write([]byte("a,b\n1,2\n"))
+	assert.Nil(t, err)
+	assert.Nil(t, writer.Close())
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), writer.FormDataContentType()
+}
+
+func TestContentType(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Event map[string]interface{}
+		Out   string
+	}{
+		{
+			Name: "it should succeed",
+			Event: map[string]interface{}{
+				"headers": map[string]interface{}{"content-type": "Application/JSON; charset=utf-8"},
+			},
+			Out: "application/json",
+		},
+		{
+			Name:  "it should handle missing header",
+			Event: map[string]interface{}{},
+			Out:   "",
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			input := http.NewInput(td.Event)
+
+			// When
+			out := input.ContentType()
+
+			// Then
+			assert.Equal(t, td.Out, out)
+		})
+	}
+}
+
+func TestForm(t *testing.T) {
+	body, contentType := multipartBody(t)
+	tests := []struct {
+		Name  string
+		Event map[string]interface{}
+		Out   url.Values
+		Error error
+	}{
+		{
+			Name: "it should succeed",
+			Event: map[string]interface{}{
+				"body":    "name=report&tag=a&tag=b",
+				"headers": map[string]interface{}{"Content-Type": "application/x-www-form-urlencoded"},
+			},
+			Out: url.Values{"name": {"report"}, "tag": {"a", "b"}},
+		},
+		{
+			Name: "it should succeed with multipart body",
+			Event: map[string]interface{}{
+				"body":            body,
+				"isBase64Encoded": true,
+				"headers":         map[string]interface{}{"Content-Type": contentType},
+			},
+			Out: url.Values{"name": {"report"}},
+		},
+		{
+			Name: "it should handle unsupported media type",
+			Event: map[string]interface{}{
+				"body":    `{"name": "report"}`,
+				"headers": map[string]interface{}{"Content-Type": "application/json"},
+			},
+			Error: http.ErrUnsupportedMediaType,
+		},
+		{
+			Name: "it should handle invalid base64",
+			Event: map[string]interface{}{
+				"body":            "not base64!",
+				"isBase64Encoded": true,
+				"headers":         map[string]interface{}{"Content-Type": "application/x-www-form-urlencoded"},
+			},
+			Error: errors.New("could not decode base64 body"),
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			input := http.NewInput(td.Event)
+
+			// When
+			out, err := input.Form()
+
+			// Then
+			assert.Equal(t, td.Error, err)
+			assert.Equal(t, td.Out, out)
+		})
+	}
+}
+
+func TestFormFile(t *testing.T) {
+	body, contentType := multipartBody(t)
+	tests := []struct {
+		Name  string
+		Field string
+		Out   *http.File
+		Error error
+	}{
+		{
+			Name:  "it should succeed",
+			Field: "file",
+			Out: &http.File{
+				Field:       "file",
+				Filename:    "report.csv",
+				ContentType: "text/csv",
+				Content:     []byte("a,b\n1,2\n"),
+			},
+		},
+		{
+			Name:  "it should handle missing file",
+			Field: "attachment",
+			Error: errors.New("missing file attachment"),
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			input := http.NewInput(map[string]interface{}{
+				"body":            body,
+				"isBase64Encoded": true,
+				"headers":         map[string]interface{}{"Content-Type": contentType},
+			})
+
+			// When
+			out, err := input.FormFile(td.Field)
+
+			// Then
+			assert.Equal(t, td.Error, err)
+			assert.Equal(t, td.Out, out)
+		})
+	}
+}
+
+type uploadRequest struct {
+	Name string     `form:"name" validate:"required"`
+	File *http.File `form:"file"`
+}
+
+func TestRouteBody(t *testing.T) {
+	body, contentType := multipartBody(t)
+	tests := []struct {
+		Name        string
+		Event       map[string]interface{}
+		MaxBodySize int64
+		StatusCode  int
+		Filename    string
+	}{
+		{
+			Name: "it should succeed",
+			Event: map[string]interface{}{
+				"body":            body,
+				"isBase64Encoded": true,
+				"headers":         map[string]interface{}{"Content-Type": contentType},
+			},
+			StatusCode: internalHTTP.StatusOK,
+			Filename:   "report.csv",
+		},
+		{
+			Name: "it should respond unsupported media type",
+			Event: map[string]interface{}{
+				"body":    "report",
+				"headers": map[string]interface{}{"Content-Type": "text/plain"},
+			},
+			StatusCode: internalHTTP.StatusUnsupportedMediaType,
+		},
+		{
+			Name: "it should respond payload too large",
+			Event: map[string]interface{}{
+				"body":            body,
+				"isBase64Encoded": true,
+				"headers":         map[string]interface{}{"Content-Type": contentType},
+			},
+			MaxBodySize: 16,
+			StatusCode:  internalHTTP.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			var filename string
+			routes := http.Routes{
+				"/upload": {
+					internalHTTP.MethodPost: http.Route{
+						Request:  uploadRequest{},
+						Consumes: []string{http.MediaTypeMultipart},
+						Handler: func(i *http.Input) domain.Response {
+							filename = i.Request().(*uploadRequest).File.Filename
+							return http.NewResponse(internalHTTP.StatusOK, nil)
+						},
+					},
+				},
+			}
+			td.Event["resource"] = "/upload"
+			td.Event["httpMethod"] = internalHTTP.MethodPost
+
+			// When
+			router := http.NewRouter(routes, nil, http.WithMaxBodySize(td.MaxBodySize))
+			res, err := router.Route(td.Event)
+
+			// Then
+			assert.Nil(t, err)
+			assert.Equal(t, td.StatusCode, res.Payload().(map[string]interface{})["statusCode"])
+			assert.Equal(t, td.Filename, filename)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package http
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/http"
+	"github.com/stretchr/testify/assert"
+	internalHTTP "net/http"
+)
+
+func TestBuilder(t *testing.T) {
+	// Given
+	var calls []string
+	middleware := func(name string) http.Middleware {
+		return func(i *http.Input) domain.Response {
+			calls = append(calls, name)
+			return nil
+		}
+	}
+	handler := func(i *http.Input) domain.Response {
+		calls = append(calls, "handler")
+		return http.NewResponse(internalHTTP.StatusOK, nil)
+	}
+
+	b := http.NewBuilder()
+	v1 := b.Group("/v1/")
+	orders := v1.Group("orders").Use(middleware("orders"))
+	orders.Handle(internalHTTP.MethodGet, "", http.Route{Handler: handler})
+	orders.Handle(internalHTTP.MethodGet, "/{id}", http.Route{
+		Handler:    handler,
+		Middleware: []http.Middleware{middleware("route")},
+	})
+	admin := b.Group("/admin").Access(&domain.Access{Roles: []string{"admin"}, Key: "cognito:groups"})
+	admin.Handle(internalHTTP.MethodDelete, "/users/{id}", http.Route{Handler: handler})
+	v1.Use(middleware("v1"))
+
+	// When
+	routes, err := b.Build()
+
+	// Then
+	assert.Nil(t, err)
+	assert.Len(t, routes, 3)
+	assert.Contains(t, routes, "/v1/orders")
+	assert.Contains(t, routes, "/admin/users/{id}")
+
+	router := http.NewRouter(routes, nil)
+	res, _ := router.Route(map[string]interface{}{
+		"resource":       "/v1/orders/1",
+		"httpMethod":     internalHTTP.MethodGet,
+		"pathParameters": map[string]interface{}{"id": "1"},
+	})
+	assert.Equal(t, internalHTTP.StatusOK, res.Payload().(map[string]interface{})["statusCode"])
+	assert.Equal(t, []string{"v1", "orders", "route", "handler"}, calls)
+
+	res, _ = router.Route(map[string]interface{}{
+		"resource":       "/admin/users/1",
+		"httpMethod":     internalHTTP.MethodDelete,
+		"pathParameters": map[string]interface{}{"id": "1"},
+		"requestContext": map[string]interface{}{
+			"authorizer": map[string]interface{}{
+				"claims": map[string]interface{}{"cognito:groups": "users"},
+			},
+		},
+	})
+	assert.Equal(t, internalHTTP.StatusForbidden, res.Payload().(map[string]interface{})["statusCode"])
+}
+
+func TestBuilderConflicts(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Register func(b *http.Builder)
+		Error    error
+	}{
+		{
+			Name: "it should succeed",
+			Register: func(b *http.Builder) {
+				b.Handle(internalHTTP.MethodGet, "/users/{id}", http.Route{})
+				b.Handle(internalHTTP.MethodPut, "/users/{id}", http.Route{})
+			},
+		},
+		{
+			Name: "it should report duplicate routes",
+			Register: func(b *http.Builder) {
+				b.Handle(internalHTTP.MethodGet, "/users/{id}", http.Route{})
+				b.Group("/users").Handle(internalHTTP.MethodGet, "{id}", http.Route{})
+			},
+			Error: errors.Join(errors.New("duplicate route GET /users/{id}")),
+		},
+		{
+			Name: "it should report conflicting path params",
+			Register: func(b *http.Builder) {
+				b.Handle(internalHTTP.MethodGet, "/users/{id}", http.Route{})
+				b.Handle(internalHTTP.MethodDelete, "/users/{userId}", http.Route{})
+			},
+			Error: errors.Join(errors.New("route DELETE /users/{userId} conflicts with /users/{id}")),
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			b := http.NewBuilder()
+			td.Register(b)
+
+			// When
+			_, err := b.Build()
+
+			// Then
+			assert.Equal(t, td.Error, err)
+		})
+	}
+}
+
+func TestBuilderNilAccess(t *testing.T) {
+	// Given
+	b := http.NewBuilder()
+	b.Group("/orders").Access(nil).Policy(nil).Handle(internalHTTP.MethodGet, "", http.Route{
+		Handler: func(i *http.Input) domain.Response {
+			return http.NewResponse(internalHTTP.StatusOK, nil)
+		},
+	})
+
+	// When
+	routes, err := b.Build()
+	router := http.NewRouter(routes, nil)
+	res, _ := router.Route(map[string]interface{}{
+		"resource":   "/orders",
+		"httpMethod": internalHTTP.MethodGet,
+	})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Nil(t, routes["/orders"][internalHTTP.MethodGet].Policy)
+	assert.Equal(t, internalHTTP.StatusOK, res.Payload().(map[string]interface{})["statusCode"])
+}
@@ -0,0 +1,218 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/http"
+	"github.com/stretchr/testify/assert"
+	internalHTTP "net/http"
+)
+
+type order struct {
+	ID    string `json:"id" csv:"id" xml:"id"`
+	Total int    `json:"total" csv:"total" xml:"total"`
+}
+
+func TestAccepts(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Accept string
+		Offers []string
+		Out    string
+	}{
+		{
+			Name:   "it should succeed",
+			Accept: "text/csv",
+			Offers: []string{"application/json", "text/csv"},
+			Out:    "text/csv",
+		},
+		{
+			Name:   "it should prefer first offer when header is missing",
+			Offers: []string{"application/json", "text/csv"},
+			Out:    "application/json",
+		},
+		{
+			Name:   "it should prefer highest quality",
+			Accept: "application/json;q=0.5, text/*;q=0.8",
+			Offers: []string{"application/json", "text/csv"},
+			Out:    "text/csv",
+		},
+		{
+			Name:   "it should prefer most specific range",
+			Accept: "*/*, text/csv;q=0",
+			Offers: []string{"text/csv", "application/xml"},
+			Out:    "application/xml",
+		},
+		{
+			Name:   "it should handle no acceptable offer",
+			Accept: "image/png",
+			Offers: []string{"application/json"},
+			Out:    "",
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			input := http.NewInput(map[string]interface{}{
+				"headers": map[string]interface{}{"accept": td.Accept},
+			})
+
+			// When
+			out := input.Accepts(td.Offers...)
+
+			// Then
+			assert.Equal(t, td.Out, out)
+		})
+	}
+}
+
+func TestResponseEncoding(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Accept      string
+		Body        interface{}
+		Produces    []string
+		Encoders    http.Encoders
+		StatusCode  int
+		ContentType string
+		Out         string
+	}{
+		{
+			Name:        "it should encode JSON by default",
+			Body:        []order{{ID: "1", Total: 10}},
+			StatusCode:  internalHTTP.StatusOK,
+			ContentType: "application/json",
+			Out:         `[{"id":"1","total":10}]`,
+		},
+		{
+			Name:        "it should encode CSV",
+			Accept:      "text/csv",
+			Body:        []order{{ID: "1", Total: 10}},
+			Produces:    []string{"application/json", "text/csv"},
+			StatusCode:  internalHTTP.StatusOK,
+			ContentType: "text/csv",
+			Out:         "id,total\n1,10\n",
+		},
+		{
+			Name:        "it should encode XML",
+			Accept:      "application/xml",
+			Body:        order{ID: "1", Total: 10},
+			Produces:    []string{"application/json", "application/xml"},
+			StatusCode:  internalHTTP.StatusOK,
+			ContentType: "application/xml",
+			Out:         "<order><id>1</id><total>10</total></order>",
+		},
+		{
+			Name:        "it should encode with custom encoder",
+			Accept:      "text/plain",
+			Body:        order{ID: "1", Total: 10},
+			Encoders:    http.Encoders{"text/plain": http.EncoderFunc(func(body interface{}) ([]byte, error) { return []byte(body.(order).ID), nil })},
+			Produces:    []string{"text/plain"},
+			StatusCode:  internalHTTP.StatusOK,
+			ContentType: "text/plain",
+			Out:         "1",
+		},
+		{
+			Name:        "it should respond not acceptable",
+			Accept:      "text/csv",
+			Body:        order{ID: "1", Total: 10},
+			Produces:    []string{"application/json"},
+			StatusCode:  internalHTTP.StatusNotAcceptable,
+			ContentType: "application/problem+json",
+			Out:         `{"detail":"None of the available media types is acceptable","status":406,"title":"Not Acceptable","type":"about:blank"}`,
+		},
+		{
+			Name:        "it should only offer JSON by default",
+			Accept:      "application/xml",
+			Body:        order{ID: "1", Total: 10},
+			StatusCode:  internalHTTP.StatusNotAcceptable,
+			ContentType: "application/problem+json",
+			Out:         `{"detail":"None of the available media types is acceptable","status":406,"title":"Not Acceptable","type":"about:blank"}`,
+		},
+		{
+			Name:        "it should not offer problem+json for success bodies",
+			Accept:      "application/problem+json",
+			Body:        order{ID: "1", Total: 10},
+			Produces:    []string{"application/json", "application/problem+json"},
+			StatusCode:  internalHTTP.StatusNotAcceptable,
+			ContentType: "application/problem+json",
+			Out:         `{"detail":"None of the available media types is acceptable","status":406,"title":"Not Acceptable","type":"about:blank"}`,
+		},
+		{
+			Name:        "it should respond internal server error on encoding failure",
+			Accept:      "text/csv",
+			Body:        map[string]string{"id": "1"},
+			Produces:    []string{"text/csv"},
+			StatusCode:  internalHTTP.StatusInternalServerError,
+			ContentType: "application/problem+json",
+			Out:         `{"status":500,"title":"Internal Server Error","type":"about:blank"}`,
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			routes := http.Routes{
+				"/orders": {
+					internalHTTP.MethodGet: http.Route{
+						Produces: td.Produces,
+						Handler: func(i *http.Input) domain.Response {
+							return http.NewResponse(internalHTTP.StatusOK, td.Body)
+						},
+					},
+				},
+			}
+			event := map[string]interface{}{
+				"resource":   "/orders",
+				"httpMethod": internalHTTP.MethodGet,
+				"headers":    map[string]interface{}{"Accept": td.Accept},
+			}
+
+			// When
+			router := http.NewRouter(routes, nil, http.WithEncoders(td.Encoders))
+			res, err := router.Route(event)
+
+			// Then
+			assert.Nil(t, err)
+			payload := res.Payload().(map[string]interface{})
+			assert.Equal(t, td.StatusCode, payload["statusCode"])
+			assert.Equal(t, td.ContentType, payload["headers"].(map[string]string)["Content-Type"])
+			assert.Equal(t, td.Out, payload["body"])
+		})
+	}
+}
+
+func TestResponsePayload(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Response   *http.Response
+		StatusCode int
+		Out        string
+	}{
+		{
+			Name:       "it should encode JSON outside of router",
+			Response:   http.NewResponse(internalHTTP.StatusOK, map[string]string{"id": "1"}),
+			StatusCode: internalHTTP.StatusOK,
+			Out:        `{"id":"1"}`,
+		},
+		{
+			Name:       "it should handle encoding failure",
+			Response:   http.NewResponse(internalHTTP.StatusOK, func() {}),
+			StatusCode: internalHTTP.StatusInternalServerError,
+			Out:        `{"error":"Internal server error"}`,
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// When
+			payload := td.Response.Payload().(map[string]interface{})
+
+			// Then
+			assert.Equal(t, td.StatusCode, payload["statusCode"])
+			assert.Equal(t, td.Out, payload["body"])
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package http
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/http"
+	"github.com/stretchr/testify/assert"
+	internalHTTP "net/http"
+)
+
+func TestErrorResponse(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Response    domain.Response
+		Formatter   http.ErrorFormatter
+		StatusCode  int
+		ContentType string
+		Out         string
+	}{
+		{
+			Name:        "it should respond problem details",
+			Response:    http.NotFound("Order 1 not found"),
+			StatusCode:  internalHTTP.StatusNotFound,
+			ContentType: "application/problem+json",
+			Out:         `{"detail":"Order 1 not found","instance":"request-id","status":404,"title":"Not Found","type":"about:blank"}`,
+		},
+		{
+			Name: "it should include type, title and extensions",
+			Response: &http.Error{
+				Status:     internalHTTP.StatusConflict,
+				Type:       "https://example.com/problems/out-of-stock",
+				Title:      "Out of stock",
+				Detail:     "Item 1 is out of stock",
+				Extensions: map[string]interface{}{"sku": "ABC-1"},
+			},
+			StatusCode:  internalHTTP.StatusConflict,
+			ContentType: "application/problem+json",
+			Out:         `{"detail":"Item 1 is out of stock","instance":"request-id","sku":"ABC-1","status":409,"title":"Out of stock","type":"https://example.com/problems/out-of-stock"}`,
+		},
+		{
+			Name:        "it should sanitize internal errors",
+			Response:    http.Internal(errors.New("connection refused to db.internal:5432")),
+			StatusCode:  internalHTTP.StatusInternalServerError,
+			ContentType: "application/problem+json",
+			Out:         `{"instance":"request-id","status":500,"title":"Internal Server Error","type":"about:blank"}`,
+		},
+		{
+			Name: "it should respond validation errors",
+			Response: http.Validation("validation failed", []domain.FieldError{
+				{Field: "email", Source: "body", Path: "email", Message: "is required"},
+			}),
+			StatusCode:  internalHTTP.StatusUnprocessableEntity,
+			ContentType: "application/problem+json",
+			Out:         `{"detail":"validation failed","errors":[{"field":"email","source":"body","path":"email","message":"is required"}],"instance":"request-id","status":422,"title":"Unprocessable Entity","type":"about:blank"}`,
+		},
+		{
+			Name:        "it should respond with configured formatter",
+			Response:    http.Conflict("Order already exists"),
+			Formatter:   http.LegacyFormatter,
+			StatusCode:  internalHTTP.StatusConflict,
+			ContentType: "application/json",
+			Out:         `{"error":"Order already exists"}`,
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			routes := http.Routes{
+				"/orders": {
+					internalHTTP.MethodPost: http.Route{
+						Handler: func(i *http.Input) domain.Response {
+							return td.Response
+						},
+					},
+				},
+			}
+			event := map[string]interface{}{
+				"resource":       "/orders",
+				"httpMethod":     internalHTTP.MethodPost,
+				"requestContext": map[string]interface{}{"requestId": "request-id"},
+			}
+			var opts []http.Option
+			if td.Formatter != nil {
+				opts = append(opts, http.WithErrorFormatter(td.Formatter))
+			}
+
+			// When
+			router := http.NewRouter(routes, nil, opts...)
+			res, err := router.Route(event)
+
+			// Then
+			assert.Nil(t, err)
+			payload := res.Payload().(map[string]interface{})
+			assert.Equal(t, td.StatusCode, payload["statusCode"])
+			assert.Equal(t, td.ContentType, payload["headers"].(map[string]string)["Content-Type"])
+			assert.Equal(t, td.Out, payload["body"])
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	// Given
+	cause := errors.New("duplicate key")
+	err := &http.Error{Status: internalHTTP.StatusConflict, Detail: "Order already exists", Err: cause}
+
+	// Then
+	assert.Equal(t, "409 Conflict: Order already exists: duplicate key", err.Error())
+	assert.True(t, errors.Is(err, cause))
+	assert.Equal(t, internalHTTP.StatusConflict, err.Payload().(map[string]interface{})["statusCode"])
+}
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/matthisstenius/lambda-router/v4/http"
+	"github.com/stretchr/testify/assert"
+	internalHTTP "net/http"
+)
+
+func TestHandle(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Status     int
+		Body       interface{}
+		Error      error
+		StatusCode int
+		Out        string
+	}{
+		{
+			Name:       "it should succeed",
+			Status:     internalHTTP.StatusCreated,
+			Body:       map[string]string{"id": "1"},
+			StatusCode: internalHTTP.StatusCreated,
+			Out:        `{"id":"1"}`,
+		},
+		{
+			Name:       "it should default to ok",
+			Body:       []string{"a"},
+			StatusCode: internalHTTP.StatusOK,
+			Out:        `["a"]`,
+		},
+		{
+			Name:       "it should respond no content without body",
+			Status:     internalHTTP.StatusNoContent,
+			StatusCode: internalHTTP.StatusNoContent,
+			Out:        "",
+		},
+		{
+			Name:       "it should respond returned response as is",
+			Body:       http.NewResponse(internalHTTP.StatusAccepted, "queued"),
+			StatusCode: internalHTTP.StatusAccepted,
+			Out:        `"queued"`,
+		},
+		{
+			Name:       "it should map typed errors",
+			Error:      fmt.Errorf("lookup: %w", http.NotFound("Order 1 not found")),
+			StatusCode: internalHTTP.StatusNotFound,
+			Out:        `{"detail":"Order 1 not found","status":404,"title":"Not Found","type":"about:blank"}`,
+		},
+		{
+			Name:       "it should map unknown errors to internal server error",
+			Error:      errors.New("connection refused"),
+			StatusCode: internalHTTP.StatusInternalServerError,
+			Out:        `{"status":500,"title":"Internal Server Error","type":"about:blank"}`,
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			routes := http.Routes{
+				"/orders": {
+					internalHTTP.MethodPost: http.Route{
+						Handler: http.Handle(td.Status, func(i *http.Input) (interface{}, error) {
+							return td.Body, td.Error
+						}),
+					},
+				},
+			}
+			event := map[string]interface{}{
+				"resource":   "/orders",
+				"httpMethod": internalHTTP.MethodPost,
+			}
+
+			// When
+			router := http.NewRouter(routes, nil)
+			res, err := router.Route(event)
+
+			// Then
+			assert.Nil(t, err)
+			payload := res.Payload().(map[string]interface{})
+			assert.Equal(t, td.StatusCode, payload["statusCode"])
+			assert.Equal(t, td.Out, payload["body"])
+		})
+	}
+}
+
+func TestHandleConcurrent(t *testing.T) {
+	// Given
+	handler := http.Handle(0, func(i *http.Input) (interface{}, error) {
+		return "ok", nil
+	})
+
+	// When
+	var wg sync.WaitGroup
+	statuses := make([]int, 10)
+	for n := range statuses {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			statuses[n] = handler(http.NewInput(map[string]interface{}{})).Payload().(map[string]interface{})["statusCode"].(int)
+		}(n)
+	}
+	wg.Wait()
+
+	// Then
+	for _, status := range statuses {
+		assert.Equal(t, internalHTTP.StatusOK, status)
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"github.com/matthisstenius/lambda-router/v4/domain"
 	"github.com/matthisstenius/lambda-router/v4/http"
 	"github.com/stretchr/testify/assert"
+	internalHTTP "net/http"
+	"net/url"
+	"strings"
 	"testing"
 )
 
@@ -200,6 +203,26 @@ func TestGetHeader(t *testing.T) {
 			Header: "Other-Header",
 			Out:    "",
 		},
+		{
+			Name: "it should match header case-insensitively",
+			Event: map[string]interface{}{
+				"headers": map[string]interface{}{
+					"content-type": "application/json",
+				},
+			},
+			Header: "Content-Type",
+			Out:    "application/json",
+		},
+		{
+			Name: "it should fall back to multi value headers",
+			Event: map[string]interface{}{
+				"multiValueHeaders": map[string]interface{}{
+					"Content-Type": []interface{}{"application/json"},
+				},
+			},
+			Header: "content-type",
+			Out:    "application/json",
+		},
 		{
 			Name:   "it should handle missing header",
 			Event:  map[string]interface{}{},
@@ -348,6 +371,22 @@ func TestParseBody(t *testing.T) {
 			},
 			Error: errors.New("could not parse body as JSON"),
 		},
+		{
+			Name: "it should decode base64 body",
+			Event: map[string]interface{}{
+				"body":            "eyJtZXNzYWdlIjogImhlbGxvLCB3b3JsZCJ9",
+				"isBase64Encoded": true,
+			},
+			Body: map[string]string{"message": "hello, world"},
+		},
+		{
+			Name: "it should decode JSON whatever the content type",
+			Event: map[string]interface{}{
+				"body":    `{"message": "hello, world"}`,
+				"headers": map[string]interface{}{"Content-Type": "text/plain"},
+			},
+			Body: map[string]string{"message": "hello, world"},
+		},
 	}
 
 	for _, td := range tests {
@@ -440,6 +479,24 @@ func TestAuth(t *testing.T) {
 			},
 			Error: errors.New("claims index missing in authorizer"),
 		},
+		{
+			Name: "it should succeed with HTTP API JWT authorizer claims",
+			Event: map[string]interface{}{
+				"requestContext": map[string]interface{}{
+					"authorizer": map[string]interface{}{
+						"jwt": map[string]interface{}{
+							"claims": map[string]interface{}{"id": "12345"},
+						},
+					},
+				},
+			},
+			Out: domain.NewAuthClaims(map[string]interface{}{"id": "12345"}),
+		},
+		{
+			Name:  "it should handle missing request context",
+			Event: map[string]interface{}{},
+			Error: errors.New("requestContext index missing in event"),
+		},
 		{
 			Name: "it should handle invalid claims JSON",
 			Event: map[string]interface{}{
@@ -467,3 +524,331 @@ func TestAuth(t *testing.T) {
 		})
 	}
 }
+
+func TestCorrelationID(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Event map[string]interface{}
+		Out   string
+	}{
+		{
+			Name: "it should succeed with correlation header",
+			Event: map[string]interface{}{
+				"headers": map[string]interface{}{
+					"x-correlation-id": "correlation-id",
+				},
+				"requestContext": map[string]interface{}{
+					"requestId": "request-id",
+				},
+			},
+			Out: "correlation-id",
+		},
+		{
+			Name: "it should fall back to request id",
+			Event: map[string]interface{}{
+				"requestContext": map[string]interface{}{
+					"requestId": "request-id",
+				},
+			},
+			Out: "request-id",
+		},
+		{
+			Name:  "it should handle missing request context",
+			Event: map[string]interface{}{},
+			Out:   "",
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			input := http.NewInput(td.Event)
+
+			// When
+			out := input.CorrelationID()
+
+			// Then
+			assert.Equal(t, td.Out, out)
+		})
+	}
+}
+
+func TestIdentity(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Event  map[string]interface{}
+		Out    *http.Identity
+		APIKey string
+	}{
+		{
+			Name: "it should succeed with REST API identity",
+			Event: map[string]interface{}{
+				"requestContext": map[string]interface{}{
+					"identity": map[string]interface{}{
+						"sourceIp":  "127.0.0.1",
+						"userArn":   "arn:aws:iam::123:user/test",
+						"accountId": "123",
+						"apiKey":    "api-key",
+					},
+				},
+			},
+			Out: &http.Identity{
+				SourceIP:  "127.0.0.1",
+				UserARN:   "arn:aws:iam::123:user/test",
+				AccountID: "123",
+				APIKey:    "api-key",
+			},
+			APIKey: "api-key",
+		},
+		{
+			Name: "it should succeed with HTTP API IAM authorizer",
+			Event: map[string]interface{}{
+				"requestContext": map[string]interface{}{
+					"authorizer": map[string]interface{}{
+						"iam": map[string]interface{}{
+							"accessKey": "AKIA",
+							"accountId": "123",
+							"userArn":   "arn:aws:iam::123:user/test",
+						},
+					},
+				},
+			},
+			Out: &http.Identity{
+				AccessKey: "AKIA",
+				AccountID: "123",
+				UserARN:   "arn:aws:iam::123:user/test",
+			},
+		},
+		{
+			Name:  "it should handle missing request context",
+			Event: map[string]interface{}{},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			input := http.NewInput(td.Event)
+
+			// When
+			out := input.Identity()
+
+			// Then
+			assert.Equal(t, td.Out, out)
+			assert.Equal(t, td.APIKey, input.APIKey())
+		})
+	}
+}
+
+func TestAuthorizerContext(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Event    map[string]interface{}
+		Out      map[string]interface{}
+		TenantID string
+	}{
+		{
+			Name: "it should succeed with REST API Lambda authorizer",
+			Event: map[string]interface{}{
+				"requestContext": map[string]interface{}{
+					"authorizer": map[string]interface{}{
+						"principalId": "user-1",
+						"tenantId":    "acme",
+						"claims":      map[string]interface{}{"sub": "1"},
+					},
+				},
+			},
+			Out:      map[string]interface{}{"principalId": "user-1", "tenantId": "acme"},
+			TenantID: "acme",
+		},
+		{
+			Name: "it should succeed with HTTP API Lambda authorizer",
+			Event: map[string]interface{}{
+				"requestContext": map[string]interface{}{
+					"authorizer": map[string]interface{}{
+						"lambda": map[string]interface{}{"tenantId": "acme"},
+					},
+				},
+			},
+			Out:      map[string]interface{}{"tenantId": "acme"},
+			TenantID: "acme",
+		},
+		{
+			Name:  "it should handle missing authorizer",
+			Event: map[string]interface{}{},
+			Out:   map[string]interface{}{},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			input := http.NewInput(td.Event)
+
+			// When
+			out := input.AuthorizerContext()
+
+			// Then
+			assert.Equal(t, td.Out, out)
+			assert.Equal(t, td.TenantID, input.GetAuthorizerValue("tenantId"))
+		})
+	}
+}
+
+func TestGetQueryParams(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Event map[string]interface{}
+		Param string
+		Out   []string
+	}{
+		{
+			Name: "it should succeed with multi value query string",
+			Event: map[string]interface{}{
+				"queryStringParameters":           map[string]interface{}{"tag": "b"},
+				"multiValueQueryStringParameters": map[string]interface{}{"tag": []interface{}{"a", "b"}},
+			},
+			Param: "tag",
+			Out:   []string{"a", "b"},
+		},
+		{
+			Name: "it should split comma joined values of HTTP API",
+			Event: map[string]interface{}{
+				"version":               "2.0",
+				"queryStringParameters": map[string]interface{}{"tag": "a,b"},
+			},
+			Param: "tag",
+			Out:   []string{"a", "b"},
+		},
+		{
+			Name: "it should read repeated values from raw query string of HTTP API",
+			Event: map[string]interface{}{
+				"version":               "2.0",
+				"rawQueryString":        "tag=a&tag=b",
+				"queryStringParameters": map[string]interface{}{"tag": "a,b"},
+			},
+			Param: "tag",
+			Out:   []string{"a", "b"},
+		},
+		{
+			Name: "it should keep comma in single value of HTTP API",
+			Event: map[string]interface{}{
+				"version":               "2.0",
+				"rawQueryString":        "q=a%2Cb",
+				"queryStringParameters": map[string]interface{}{"q": "a,b"},
+			},
+			Param: "q",
+			Out:   []string{"a,b"},
+		},
+		{
+			Name: "it should succeed with single value",
+			Event: map[string]interface{}{
+				"queryStringParameters": map[string]interface{}{"tag": "a,b"},
+			},
+			Param: "tag",
+			Out:   []string{"a,b"},
+		},
+		{
+			Name:  "it should handle missing param",
+			Event: map[string]interface{}{},
+			Param: "tag",
+			Out:   []string{},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			input := http.NewInput(td.Event)
+
+			// When
+			out := input.GetQueryParams(td.Param)
+
+			// Then
+			assert.Equal(t, td.Out, out)
+		})
+	}
+}
+
+func TestGetHeaderValuesHTTPAPI(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Header string
+		Value  string
+		Out    []string
+	}{
+		{
+			Name:   "it should split list headers",
+			Header: "Accept",
+			Value:  "application/json, text/csv",
+			Out:    []string{"application/json", "text/csv"},
+		},
+		{
+			Name:   "it should keep commas in quoted strings",
+			Header: "If-None-Match",
+			Value:  `"a,b", "c"`,
+			Out:    []string{`"a,b"`, `"c"`},
+		},
+		{
+			Name:   "it should not split other headers",
+			Header: "If-Modified-Since",
+			Value:  "Wed, 21 Oct 2015 07:28:00 GMT",
+			Out:    []string{"Wed, 21 Oct 2015 07:28:00 GMT"},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			input := http.NewInput(map[string]interface{}{
+				"version": "2.0",
+				"headers": map[string]interface{}{strings.ToLower(td.Header): td.Value},
+			})
+
+			// When
+			out := input.GetHeaderValues(td.Header)
+
+			// Then
+			assert.Equal(t, td.Out, out)
+			assert.Equal(t, td.Value, input.GetHeader(td.Header))
+		})
+	}
+}
+
+func TestGetHeaderValues(t *testing.T) {
+	// Given
+	input := http.NewInput(map[string]interface{}{
+		"headers": map[string]interface{}{"accept": "text/csv"},
+		"multiValueHeaders": map[string]interface{}{
+			"accept": []interface{}{"application/json", "text/csv"},
+		},
+	})
+
+	// When, Then
+	assert.Equal(t, []string{"application/json", "text/csv"}, input.GetHeaderValues("Accept"))
+	assert.Equal(t, []string{}, input.GetHeaderValues("X-Missing"))
+}
+
+func TestValueViews(t *testing.T) {
+	// Given
+	input := http.NewInput(map[string]interface{}{
+		"headers": map[string]interface{}{"content-type": "application/json"},
+		"multiValueHeaders": map[string]interface{}{
+			"Accept": []interface{}{"application/json", "text/csv"},
+		},
+		"multiValueQueryStringParameters": map[string]interface{}{
+			"tag": []interface{}{"a", "b"},
+		},
+	})
+
+	// When
+	query := input.QueryValues()
+	header := input.Header()
+
+	// Then
+	assert.Equal(t, url.Values{"tag": {"a", "b"}}, query)
+	assert.Equal(t, internalHTTP.Header{
+		"Accept":       {"application/json", "text/csv"},
+		"Content-Type": {"application/json"},
+	}, header)
+}
@@ -0,0 +1,168 @@
+package http
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matthisstenius/lambda-router/v4/http"
+	"github.com/stretchr/testify/assert"
+)
+
+type metadata struct {
+	Method      string
+	RequestID   string
+	Stage       string
+	APIID       string
+	DomainName  string
+	Protocol    string
+	SourceIP    string
+	UserAgent   string
+	RequestTime time.Time
+	RawPath     string
+	Path        string
+	URL         string
+}
+
+func TestRequestMetadata(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Event map[string]interface{}
+		Out   metadata
+	}{
+		{
+			Name: "it should succeed with REST API event",
+			Event: map[string]interface{}{
+				"resource":       "/users/{id}",
+				"path":           "/v1/users/1",
+				"httpMethod":     "GET",
+				"pathParameters": map[string]interface{}{"id": "1"},
+				"multiValueQueryStringParameters": map[string]interface{}{
+					"tag": []interface{}{"a", "b"},
+				},
+				"requestContext": map[string]interface{}{
+					"requestId":        "request-id",
+					"stage":            "prod",
+					"apiId":            "api-id",
+					"domainName":       "api.example.com",
+					"protocol":         "HTTP/1.1",
+					"requestTimeEpoch": float64(1700000000123),
+					"identity": map[string]interface{}{
+						"sourceIp":  "10.0.0.1",
+						"userAgent": "test-agent",
+					},
+				},
+			},
+			Out: metadata{
+				Method:      "GET",
+				RequestID:   "request-id",
+				Stage:       "prod",
+				APIID:       "api-id",
+				DomainName:  "api.example.com",
+				Protocol:    "HTTP/1.1",
+				SourceIP:    "10.0.0.1",
+				UserAgent:   "test-agent",
+				RequestTime: time.Unix(1700000000, 123000000).UTC(),
+				RawPath:     "/v1/users/1",
+				Path:        "/users/1",
+				URL:         "https://api.example.com/users/1?tag=a&tag=b",
+			},
+		},
+		{
+			Name: "it should succeed with HTTP API event",
+			Event: map[string]interface{}{
+				"version":        "2.0",
+				"routeKey":       "$default",
+				"rawPath":        "/staging/users/1",
+				"rawQueryString": "limit=10",
+				"requestContext": map[string]interface{}{
+					"requestId":  "request-id",
+					"stage":      "staging",
+					"apiId":      "api-id",
+					"domainName": "api.example.com",
+					"timeEpoch":  float64(1700000000123),
+					"http": map[string]interface{}{
+						"method":    "POST",
+						"protocol":  "HTTP/1.1",
+						"sourceIp":  "10.0.0.2",
+						"userAgent": "test-agent",
+					},
+				},
+			},
+			Out: metadata{
+				Method:      "POST",
+				RequestID:   "request-id",
+				Stage:       "staging",
+				APIID:       "api-id",
+				DomainName:  "api.example.com",
+				Protocol:    "HTTP/1.1",
+				SourceIP:    "10.0.0.2",
+				UserAgent:   "test-agent",
+				RequestTime: time.Unix(1700000000, 123000000).UTC(),
+				RawPath:     "/staging/users/1",
+				Path:        "/users/1",
+				URL:         "https://api.example.com/users/1?limit=10",
+			},
+		},
+		{
+			Name: "it should succeed with ALB event",
+			Event: map[string]interface{}{
+				"httpMethod": "GET",
+				"path":       "/users/1",
+				"headers": map[string]interface{}{
+					"host":              "alb.example.com",
+					"user-agent":        "test-agent",
+					"x-forwarded-for":   "10.0.0.3, 10.0.0.4",
+					"x-forwarded-proto": "http",
+				},
+				"queryStringParameters": map[string]interface{}{"limit": "10"},
+			},
+			Out: metadata{
+				Method:     "GET",
+				DomainName: "alb.example.com",
+				SourceIP:   "10.0.0.3",
+				UserAgent:  "test-agent",
+				RawPath:    "/users/1",
+				Path:       "/users/1",
+				URL:        "http://alb.example.com/users/1?limit=10",
+			},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			input := http.NewInput(td.Event)
+
+			// When
+			out := metadata{
+				Method:      input.Method(),
+				RequestID:   input.RequestID(),
+				Stage:       input.Stage(),
+				APIID:       input.APIID(),
+				DomainName:  input.DomainName(),
+				Protocol:    input.Protocol(),
+				SourceIP:    input.SourceIP(),
+				UserAgent:   input.UserAgent(),
+				RequestTime: input.RequestTime(),
+				RawPath:     input.RawPath(),
+				Path:        input.Path(),
+				URL:         input.URL().String(),
+			}
+
+			// Then
+			assert.Equal(t, td.Out, out)
+		})
+	}
+}
+
+func TestStageVariables(t *testing.T) {
+	// Given
+	input := http.NewInput(map[string]interface{}{
+		"stageVariables": map[string]interface{}{"tableName": "users"},
+	})
+
+	// When, Then
+	assert.Equal(t, map[string]string{"tableName": "users"}, input.StageVariables())
+	assert.Equal(t, "users", input.GetStageVariable("tableName"))
+	assert.Equal(t, "", input.GetStageVariable("missing"))
+}
@@ -163,6 +163,50 @@ func TestRoute(t *testing.T) {
 			HTTPMethod: internalHTTP.MethodGet,
 			StatusCode: internalHTTP.StatusOK,
 		},
+		{
+			Name: "it should succeed with equal path param values",
+			Event: map[string]interface{}{
+				"resource":       "/test/1/other/1",
+				"httpMethod":     internalHTTP.MethodGet,
+				"pathParameters": map[string]interface{}{"id": "1", "otherID": "1"},
+			},
+			Path:       "/test/{id}/other/{otherID}",
+			HTTPMethod: internalHTTP.MethodGet,
+			StatusCode: internalHTTP.StatusOK,
+		},
+		{
+			Name: "it should succeed with path param value equal to literal segment",
+			Event: map[string]interface{}{
+				"resource":       "/test/test",
+				"httpMethod":     internalHTTP.MethodGet,
+				"pathParameters": map[string]interface{}{"id": "test"},
+			},
+			Path:       "/test/{id}",
+			HTTPMethod: internalHTTP.MethodGet,
+			StatusCode: internalHTTP.StatusOK,
+		},
+		{
+			Name: "it should succeed with greedy path params",
+			Event: map[string]interface{}{
+				"resource":       "/files/1/docs/report.pdf",
+				"httpMethod":     internalHTTP.MethodGet,
+				"pathParameters": map[string]interface{}{"id": "1", "path": "docs/report.pdf"},
+			},
+			Path:       "/files/{id}/{path+}",
+			HTTPMethod: internalHTTP.MethodGet,
+			StatusCode: internalHTTP.StatusOK,
+		},
+		{
+			Name: "it should succeed with resource template",
+			Event: map[string]interface{}{
+				"resource":       "/test/{id}",
+				"httpMethod":     internalHTTP.MethodGet,
+				"pathParameters": map[string]interface{}{"id": "1"},
+			},
+			Path:       "/test/{id}",
+			HTTPMethod: internalHTTP.MethodGet,
+			StatusCode: internalHTTP.StatusOK,
+		},
 		{
 			Name: "it should handle path mismatch",
 			Event: map[string]interface{}{
@@ -268,3 +312,96 @@ func TestIsMatch(t *testing.T) {
 		})
 	}
 }
+
+func TestCorrelationIDHeader(t *testing.T) {
+	// Given
+	routes := http.Routes{
+		"/test/path": {
+			internalHTTP.MethodGet: http.Route{
+				Handler: func(i *http.Input) domain.Response {
+					return http.NewResponse(internalHTTP.StatusOK, "")
+				},
+			},
+		},
+	}
+	router := http.NewRouter(routes, nil)
+
+	// When
+	res, err := router.Route(map[string]interface{}{
+		"resource":   "/test/path",
+		"httpMethod": internalHTTP.MethodGet,
+		"requestContext": map[string]interface{}{
+			"requestId": "request-id",
+		},
+	})
+
+	// Then
+	assert.Nil(t, err)
+	headers := res.Payload().(map[string]interface{})["headers"].(map[string]string)
+	assert.Equal(t, "request-id", headers[domain.CorrelationIDHeader])
+}
+
+func TestPolicy(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Event      map[string]interface{}
+		StatusCode int
+	}{
+		{
+			Name: "it should succeed",
+			Event: map[string]interface{}{
+				"requestContext": map[string]interface{}{
+					"authorizer": map[string]interface{}{
+						"claims": map[string]interface{}{"sub": "123"},
+					},
+				},
+			},
+			StatusCode: internalHTTP.StatusOK,
+		},
+		{
+			Name: "it should handle unauthenticated request",
+			Event: map[string]interface{}{
+				"requestContext": map[string]interface{}{},
+			},
+			StatusCode: internalHTTP.StatusUnauthorized,
+		},
+		{
+			Name: "it should handle forbidden request",
+			Event: map[string]interface{}{
+				"requestContext": map[string]interface{}{
+					"authorizer": map[string]interface{}{
+						"claims": map[string]interface{}{"sub": "456"},
+					},
+				},
+			},
+			StatusCode: internalHTTP.StatusForbidden,
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			routes := http.Routes{
+				"/users/{userId}": {
+					internalHTTP.MethodGet: http.Route{
+						Handler: func(i *http.Input) domain.Response {
+							return http.NewResponse(internalHTTP.StatusOK, "")
+						},
+						Policy: domain.Owner("userId"),
+					},
+				},
+			}
+			td.Event["resource"] = "/users/123"
+			td.Event["httpMethod"] = internalHTTP.MethodGet
+			td.Event["pathParameters"] = map[string]interface{}{"userId": "123"}
+
+			// When
+			router := http.NewRouter(routes, nil)
+			res, err := router.Route(td.Event)
+
+			// Then
+			assert.Nil(t, err)
+			assert.Equal(t, td.StatusCode, res.Payload().(map[string]interface{})["statusCode"])
+		})
+	}
+}
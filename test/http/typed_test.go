@@ -0,0 +1,112 @@
+package http
+
+import (
+	"context"
+	"testing"
+
+	"github.com/matthisstenius/lambda-router/v4/http"
+	"github.com/stretchr/testify/assert"
+	internalHTTP "net/http"
+)
+
+type createOrder struct {
+	Tenant string `header:"X-Tenant" validate:"required"`
+	SKU    string `json:"sku" validate:"required"`
+}
+
+type createdOrder struct {
+	ID     string `json:"id"`
+	Tenant string `json:"tenant"`
+	SKU    string `json:"sku"`
+}
+
+func TestJSON(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Route      http.Route
+		Event      map[string]interface{}
+		StatusCode int
+		Out        string
+	}{
+		{
+			Name: "it should succeed",
+			Route: http.JSON(internalHTTP.StatusCreated, func(ctx context.Context, req createOrder) (createdOrder, error) {
+				i, _ := http.InputFromContext(ctx)
+				return createdOrder{ID: i.RequestID(), Tenant: req.Tenant, SKU: req.SKU}, nil
+			}),
+			Event: map[string]interface{}{
+				"headers":        map[string]interface{}{"X-Tenant": "acme"},
+				"body":           `{"sku": "ABC-1"}`,
+				"requestContext": map[string]interface{}{"requestId": "1"},
+			},
+			StatusCode: internalHTTP.StatusCreated,
+			Out:        `{"id":"1","tenant":"acme","sku":"ABC-1"}`,
+		},
+		{
+			Name: "it should validate struct requests",
+			Route: http.JSON(internalHTTP.StatusCreated, func(ctx context.Context, req createOrder) (createdOrder, error) {
+				return createdOrder{}, nil
+			}),
+			Event: map[string]interface{}{
+				"body": `{"sku": "ABC-1"}`,
+			},
+			StatusCode: internalHTTP.StatusUnprocessableEntity,
+			Out:        `{"detail":"validation failed","errors":[{"field":"X-Tenant","source":"header","path":"X-Tenant","message":"is required"}],"status":422,"title":"Unprocessable Entity","type":"about:blank"}`,
+		},
+		{
+			Name: "it should decode non struct requests",
+			Route: http.JSON(internalHTTP.StatusOK, func(ctx context.Context, req []string) (int, error) {
+				return len(req), nil
+			}),
+			Event: map[string]interface{}{
+				"body": `["a", "b"]`,
+			},
+			StatusCode: internalHTTP.StatusOK,
+			Out:        `2`,
+		},
+		{
+			Name: "it should handle invalid body",
+			Route: http.JSON(internalHTTP.StatusOK, func(ctx context.Context, req []string) (int, error) {
+				return len(req), nil
+			}),
+			Event: map[string]interface{}{
+				"body": `{"sku": "ABC-1"}`,
+			},
+			StatusCode: internalHTTP.StatusBadRequest,
+			Out:        `{"detail":"could not parse body as JSON","status":400,"title":"Bad Request","type":"about:blank"}`,
+		},
+		{
+			Name: "it should map handler errors",
+			Route: http.JSON(internalHTTP.StatusCreated, func(ctx context.Context, req createOrder) (createdOrder, error) {
+				return createdOrder{}, http.Conflict("Order already exists")
+			}),
+			Event: map[string]interface{}{
+				"headers": map[string]interface{}{"X-Tenant": "acme"},
+				"body":    `{"sku": "ABC-1"}`,
+			},
+			StatusCode: internalHTTP.StatusConflict,
+			Out:        `{"detail":"Order already exists","status":409,"title":"Conflict","type":"about:blank"}`,
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			routes := http.Routes{
+				"/orders": {internalHTTP.MethodPost: td.Route},
+			}
+			td.Event["resource"] = "/orders"
+			td.Event["httpMethod"] = internalHTTP.MethodPost
+
+			// When
+			router := http.NewRouter(routes, nil)
+			res, err := router.Route(td.Event)
+
+			// Then
+			assert.Nil(t, err)
+			payload := res.Payload().(map[string]interface{})
+			assert.Equal(t, td.StatusCode, payload["statusCode"])
+			assert.Equal(t, td.Out, payload["body"])
+		})
+	}
+}
@@ -0,0 +1,351 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	internalHTTP "net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/http"
+	"github.com/matthisstenius/lambda-router/v4/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	rsaKey *rsa.PrivateKey
+	ecKey  *ecdsa.PrivateKey
+	now    = time.Unix(1700000000, 0)
+)
+
+func init() {
+	rsaKey, _ = rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+}
+
+func jwks() jwt.JWKS {
+	return jwt.JWKS{Keys: []jwt.JWK{
+		{
+			Kty: "RSA",
+			Kid: "rsa-key",
+			N:   base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes()),
+		},
+		{
+			Kty: "EC",
+			Kid: "ec-key",
+			Crv: "P-256",
+			X:   base64.RawURLEncoding.EncodeToString(ecKey.X.FillBytes(make([]byte, 32))),
+			Y:   base64.RawURLEncoding.EncodeToString(ecKey.Y.FillBytes(make([]byte, 32))),
+		},
+	}}
+}
+
+func sign(alg string, kid string, claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	hash := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	switch alg {
+	case "RS256":
+		signature, _ = rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, hash[:])
+	case "ES256":
+		r, s, _ := ecdsa.Sign(rand.Reader, ecKey, hash[:])
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// Replaces payload while keeping the original signature
+func tamper(token string, claims map[string]interface{}) string {
+	parts := strings.Split(token, ".")
+	payload, _ := json.Marshal(claims)
+	return parts[0] + "." + base64.RawURLEncoding.EncodeToString(payload) + "." + parts[2]
+}
+
+func writeJWKS(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	encoded, _ := json.Marshal(jwks())
+	if err := os.WriteFile(path, encoded, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestVerify(t *testing.T) {
+	valid := map[string]interface{}{
+		"sub": "123",
+		"iss": "https://issuer.test",
+		"aud": "api",
+		"exp": float64(now.Add(time.Hour).Unix()),
+		"nbf": float64(now.Add(-time.Minute).Unix()),
+	}
+	with := func(key string, value interface{}) map[string]interface{} {
+		claims := map[string]interface{}{}
+		for k, v := range valid {
+			claims[k] = v
+		}
+		claims[key] = value
+		return claims
+	}
+
+	tests := []struct {
+		Name  string
+		Token string
+		Error error
+	}{
+		{
+			Name:  "it should succeed with RS256",
+			Token: sign("RS256", "rsa-key", valid),
+		},
+		{
+			Name:  "it should succeed with ES256",
+			Token: sign("ES256", "ec-key", valid),
+		},
+		{
+			Name:  "it should succeed with audience array",
+			Token: sign("RS256", "rsa-key", with("aud", []interface{}{"other", "api"})),
+		},
+		{
+			Name:  "it should handle invalid signature",
+			Token: tamper(sign("RS256", "rsa-key", valid), with("sub", "456")),
+			Error: jwt.ErrInvalidSignature,
+		},
+		{
+			Name:  "it should handle key algorithm mismatch",
+			Token: sign("ES256", "rsa-key", valid),
+			Error: jwt.ErrInvalidSignature,
+		},
+		{
+			Name:  "it should handle unsupported algorithm",
+			Token: sign("HS256", "rsa-key", valid),
+			Error: jwt.ErrUnsupportedAlg,
+		},
+		{
+			Name:  "it should handle expired token",
+			Token: sign("RS256", "rsa-key", with("exp", float64(now.Add(-time.Hour).Unix()))),
+			Error: jwt.ErrExpired,
+		},
+		{
+			Name:  "it should handle token not yet valid",
+			Token: sign("RS256", "rsa-key", with("nbf", float64(now.Add(time.Hour).Unix()))),
+			Error: jwt.ErrNotYetValid,
+		},
+		{
+			Name:  "it should handle issuer mismatch",
+			Token: sign("RS256", "rsa-key", with("iss", "https://other.test")),
+			Error: jwt.ErrInvalidIssuer,
+		},
+		{
+			Name:  "it should handle audience mismatch",
+			Token: sign("RS256", "rsa-key", with("aud", "other")),
+			Error: jwt.ErrInvalidAudience,
+		},
+		{
+			Name:  "it should handle malformed token",
+			Token: "not-a-token",
+			Error: jwt.ErrMalformed,
+		},
+	}
+
+	path := writeJWKS(t)
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			verifier := jwt.NewVerifier(jwt.Config{
+				Issuer:   "https://issuer.test",
+				Audience: []string{"api"},
+				JWKSFile: path,
+				Now:      func() time.Time { return now },
+			})
+
+			// When
+			claims, err := verifier.Verify(td.Token)
+
+			// Then
+			assert.Equal(t, td.Error, err)
+			if td.Error == nil {
+				assert.Equal(t, "123", claims["sub"])
+			}
+		})
+	}
+}
+
+func TestJWKSURLCache(t *testing.T) {
+	// Given
+	requests := 0
+	server := httptest.NewServer(internalHTTP.HandlerFunc(func(w internalHTTP.ResponseWriter, r *internalHTTP.Request) {
+		requests++
+		json.NewEncoder(w).Encode(jwks())
+	}))
+	defer server.Close()
+
+	current := now
+	verifier := jwt.NewVerifier(jwt.Config{
+		JWKSURL:  server.URL,
+		CacheTTL: time.Hour,
+		Now:      func() time.Time { return current },
+	})
+	token := sign("RS256", "rsa-key", map[string]interface{}{"exp": float64(now.Add(2 * time.Hour).Unix())})
+
+	// When
+	_, err1 := verifier.Verify(token)
+	_, err2 := verifier.Verify(token)
+	current = now.Add(90 * time.Minute)
+	_, err3 := verifier.Verify(token)
+
+	// Then
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Nil(t, err3)
+	assert.Equal(t, 2, requests)
+}
+
+func TestJWKSStaleKeys(t *testing.T) {
+	// Given
+	requests := 0
+	available := true
+	server := httptest.NewServer(internalHTTP.HandlerFunc(func(w internalHTTP.ResponseWriter, r *internalHTTP.Request) {
+		requests++
+		if !available {
+			w.WriteHeader(internalHTTP.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(jwks())
+	}))
+	defer server.Close()
+
+	current := now
+	verifier := jwt.NewVerifier(jwt.Config{
+		JWKSURL:  server.URL,
+		CacheTTL: time.Hour,
+		Now:      func() time.Time { return current },
+	})
+	token := sign("RS256", "rsa-key", map[string]interface{}{"exp": float64(now.Add(3 * time.Hour).Unix())})
+
+	// When
+	_, err1 := verifier.Verify(token)
+	available = false
+	current = now.Add(90 * time.Minute)
+	_, err2 := verifier.Verify(token)
+	_, err3 := verifier.Verify(token)
+	available = true
+	current = now.Add(92 * time.Minute)
+	_, err4 := verifier.Verify(token)
+
+	// Then
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Nil(t, err3)
+	assert.Nil(t, err4)
+	assert.Equal(t, 3, requests)
+}
+
+func TestJWKSUnavailable(t *testing.T) {
+	// Given
+	requests := 0
+	available := false
+	server := httptest.NewServer(internalHTTP.HandlerFunc(func(w internalHTTP.ResponseWriter, r *internalHTTP.Request) {
+		requests++
+		if !available {
+			w.WriteHeader(internalHTTP.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(jwks())
+	}))
+	defer server.Close()
+
+	current := now
+	verifier := jwt.NewVerifier(jwt.Config{
+		JWKSURL:  server.URL,
+		CacheTTL: time.Hour,
+		Now:      func() time.Time { return current },
+	})
+	token := sign("RS256", "rsa-key", map[string]interface{}{"exp": float64(now.Add(time.Hour).Unix())})
+
+	// When
+	_, err1 := verifier.Verify(token)
+	available = true
+	current = now.Add(30 * time.Second)
+	_, err2 := verifier.Verify(token)
+	current = now.Add(2 * time.Minute)
+	_, err3 := verifier.Verify(token)
+
+	// Then
+	assert.NotNil(t, err1)
+	assert.Equal(t, err1, err2)
+	assert.Nil(t, err3)
+	assert.Equal(t, 2, requests)
+}
+
+func TestRouterBearerAuth(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Headers    map[string]interface{}
+		StatusCode int
+	}{
+		{
+			Name: "it should succeed",
+			Headers: map[string]interface{}{
+				"authorization": "Bearer " + sign("RS256", "rsa-key", map[string]interface{}{
+					"cognito:groups": []interface{}{"Admin"},
+					"exp":            float64(time.Now().Add(time.Hour).Unix()),
+				}),
+			},
+			StatusCode: internalHTTP.StatusOK,
+		},
+		{
+			Name:       "it should handle missing token",
+			Headers:    map[string]interface{}{},
+			StatusCode: internalHTTP.StatusUnauthorized,
+		},
+		{
+			Name:       "it should handle invalid token",
+			Headers:    map[string]interface{}{"Authorization": "Bearer invalid"},
+			StatusCode: internalHTTP.StatusUnauthorized,
+		},
+	}
+
+	path := writeJWKS(t)
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			routes := http.Routes{
+				"/test/path": {
+					internalHTTP.MethodGet: http.Route{
+						Handler: func(i *http.Input) domain.Response {
+							return http.NewResponse(internalHTTP.StatusOK, "")
+						},
+						Access: &domain.Access{Roles: []string{"Admin"}, Key: "cognito:groups"},
+					},
+				},
+			}
+			router := http.NewRouter(routes, nil, http.WithTokenVerifier(jwt.NewVerifier(jwt.Config{JWKSFile: path})))
+
+			// When
+			res, err := router.Route(map[string]interface{}{
+				"resource":   "/test/path",
+				"httpMethod": internalHTTP.MethodGet,
+				"headers":    td.Headers,
+			})
+
+			// Then
+			assert.Nil(t, err)
+			assert.Equal(t, td.StatusCode, res.Payload().(map[string]interface{})["statusCode"])
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlog(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Log   func(l domain.Logger)
+		Level string
+		Out   map[string]interface{}
+	}{
+		{
+			Name: "it should log on info level",
+			Log: func(l domain.Logger) {
+				l.Info("test message", domain.Fields{"key": "value"})
+			},
+			Out: map[string]interface{}{"level": "INFO", "msg": "test message", "key": "value"},
+		},
+		{
+			Name: "it should log on error level",
+			Log: func(l domain.Logger) {
+				l.Error("test message", nil)
+			},
+			Out: map[string]interface{}{"level": "ERROR", "msg": "test message"},
+		},
+		{
+			Name: "it should log on debug level",
+			Log: func(l domain.Logger) {
+				l.Debug("test message", nil)
+			},
+			Out: map[string]interface{}{"level": "DEBUG", "msg": "test message"},
+		},
+		{
+			Name: "it should attach request scoped fields",
+			Log: func(l domain.Logger) {
+				l.With(domain.Fields{"requestId": "123"}).Info("test message", domain.Fields{"key": "value"})
+			},
+			Out: map[string]interface{}{"level": "INFO", "msg": "test message", "key": "value", "requestId": "123"},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			var buf bytes.Buffer
+			handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+				Level: slog.LevelDebug,
+				ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+					if a.Key == slog.TimeKey {
+						return slog.Attr{}
+					}
+					return a
+				},
+			})
+			logger := logging.NewSlog(slog.New(handler))
+
+			// When
+			td.Log(logger)
+
+			// Then
+			var out map[string]interface{}
+			assert.Nil(t, json.Unmarshal(buf.Bytes(), &out))
+			assert.Equal(t, td.Out, out)
+		})
+	}
+}
+
+func TestNop(t *testing.T) {
+	// Given
+	logger := logging.Nop()
+
+	// When, Then
+	logger.With(domain.Fields{"key": "value"}).Info("test message", nil)
+	logger.Debug("test message", nil)
+	logger.Error("test message", nil)
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/matthisstenius/lambda-router/v4/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlush(t *testing.T) {
+	tests := []struct {
+		Name string
+		Put  func(m *metrics.Metrics)
+		Out  string
+	}{
+		{
+			Name: "it should succeed",
+			Put: func(m *metrics.Metrics) {
+				m.SetDimension("Source", "http")
+				m.Put("Invocations", 1, metrics.UnitCount)
+			},
+			Out: `{"Invocations":1,"Source":"http","_aws":{"CloudWatchMetrics":[{"Dimensions":[["Source"]],"Metrics":[{"Name":"Invocations","Unit":"Count"}],"Namespace":"Test"}],"Timestamp":1000}}` + "\n",
+		},
+		{
+			Name: "it should emit repeated values as array",
+			Put: func(m *metrics.Metrics) {
+				m.Put("Latency", 1, metrics.UnitMilliseconds)
+				m.Put("Latency", 2, metrics.UnitMilliseconds)
+			},
+			Out: `{"Latency":[1,2],"_aws":{"CloudWatchMetrics":[{"Dimensions":[[]],"Metrics":[{"Name":"Latency","Unit":"Milliseconds"}],"Namespace":"Test"}],"Timestamp":1000}}` + "\n",
+		},
+		{
+			Name: "it should include properties",
+			Put: func(m *metrics.Metrics) {
+				m.SetProperty("requestId", "123")
+			},
+			Out: `{"_aws":{"CloudWatchMetrics":[{"Dimensions":[[]],"Metrics":[],"Namespace":"Test"}],"Timestamp":1000},"requestId":"123"}` + "\n",
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			var buf bytes.Buffer
+			m := metrics.New("Test")
+			m.SetTimestamp(time.Unix(1, 0))
+			td.Put(m)
+
+			// When
+			err := m.Flush(&buf)
+
+			// Then
+			assert.Nil(t, err)
+			assert.Equal(t, td.Out, buf.String())
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	// Given
+	m := metrics.New("Test")
+	ctx := metrics.NewContext(context.Background(), m)
+
+	// When, Then
+	assert.Equal(t, m, metrics.FromContext(ctx))
+	assert.NotNil(t, metrics.FromContext(context.Background()))
+}
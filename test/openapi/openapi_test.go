@@ -0,0 +1,126 @@
+package openapi
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/http"
+	"github.com/matthisstenius/lambda-router/v4/openapi"
+	"github.com/stretchr/testify/assert"
+	internalHTTP "net/http"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+type listOrders struct {
+	Status string `query:"status" validate:"oneof=open closed"`
+	Limit  int    `query:"limit" validate:"min=1,max=100"`
+	Tenant string `header:"X-Tenant" validate:"required"`
+}
+
+type item struct {
+	SKU      string `json:"sku" validate:"required,regex=^[A-Z]{3}-[0-9]+$"`
+	Quantity int    `json:"quantity" validate:"min=1"`
+}
+
+type createOrder struct {
+	Email string   `json:"email" validate:"required,email"`
+	Items []item   `json:"items" validate:"required,max=10"`
+	Tags  []string `json:"tags,omitempty" validate:"dive,max=20"`
+}
+
+type getOrder struct {
+	ID string `path:"id" validate:"uuid"`
+}
+
+type order struct {
+	ID        string            `json:"id"`
+	Email     string            `json:"email"`
+	Items     []item            `json:"items"`
+	Total     float64           `json:"total"`
+	CreatedAt time.Time         `json:"createdAt"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	Internal  string            `json:"-"`
+}
+
+func routes() http.Routes {
+	handler := func(i *http.Input) domain.Response { return nil }
+	return http.Routes{
+		"/orders": {
+			internalHTTP.MethodGet: http.Route{
+				Handler:  handler,
+				Request:  listOrders{},
+				Response: []order{},
+				Doc:      &http.Doc{Summary: "List orders", Tags: []string{"orders"}},
+			},
+			internalHTTP.MethodPost: http.JSON(internalHTTP.StatusCreated, func(ctx context.Context, req createOrder) (order, error) {
+				return order{}, nil
+			}),
+		},
+		"/orders/{id}": {
+			internalHTTP.MethodGet: http.Route{
+				Handler:  handler,
+				Request:  getOrder{},
+				Response: order{},
+				Access:   &domain.Access{Roles: []string{"admin", "support"}, Key: "cognito:groups"},
+			},
+			internalHTTP.MethodDelete: http.Route{
+				Handler: handler,
+				Status:  internalHTTP.StatusNoContent,
+				Policy:  domain.Owner("id"),
+				Doc:     &http.Doc{OperationID: "cancelOrder", Description: "Cancels an open order"},
+			},
+		},
+		"/files/{path+}": {
+			internalHTTP.MethodGet: http.Route{Handler: handler},
+		},
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Golden string
+		Encode func(doc *openapi.Document) ([]byte, error)
+	}{
+		{
+			Name:   "it should generate JSON",
+			Golden: "openapi.json",
+			Encode: (*openapi.Document).JSON,
+		},
+		{
+			Name:   "it should generate YAML",
+			Golden: "openapi.yaml",
+			Encode: (*openapi.Document).YAML,
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			config := openapi.Config{
+				Info:    openapi.Info{Title: "Orders", Version: "1.0.0"},
+				Servers: []openapi.Server{{URL: "https://api.example.com"}},
+			}
+
+			// When
+			doc := openapi.Generate(routes(), config)
+			out, err := td.Encode(doc)
+
+			// Then
+			assert.Nil(t, err)
+			golden := filepath.Join("testdata", td.Golden)
+			if *update {
+				assert.Nil(t, ioutil.WriteFile(golden, out, 0644))
+			}
+			expected, err := ioutil.ReadFile(golden)
+			assert.Nil(t, err)
+			assert.Equal(t, string(expected), string(out))
+		})
+	}
+}
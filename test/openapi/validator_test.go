@@ -0,0 +1,409 @@
+package openapi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/http"
+	"github.com/matthisstenius/lambda-router/v4/openapi"
+	"github.com/stretchr/testify/assert"
+	internalHTTP "net/http"
+)
+
+const spec = `
+openapi: 3.1.0
+info:
+  title: Orders
+  version: 1.0.0
+paths:
+  /orders/{id}:
+    parameters:
+      - name: id
+        in: path
+    put:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+            minimum: 1
+        - name: dryRun
+          in: query
+          schema:
+            type: boolean
+        - name: X-Tenant
+          in: header
+          required: true
+          schema:
+            type: string
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/order'
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/order'
+        4XX:
+          description: Client error
+          content:
+            application/problem+json:
+              schema:
+                type: object
+components:
+  schemas:
+    order:
+      type: object
+      required: [sku]
+      properties:
+        sku:
+          type: string
+          pattern: ^[A-Z]{3}-[0-9]+$
+        quantity:
+          type: integer
+          minimum: 1
+        tags:
+          type: array
+          maxItems: 2
+          items:
+            type: string
+`
+
+func TestValidator(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Event      map[string]interface{}
+		Handler    func(i *http.Input) domain.Response
+		StatusCode int
+		Out        string
+	}{
+		{
+			Name: "it should succeed",
+			Event: map[string]interface{}{
+				"pathParameters":        map[string]interface{}{"id": "1"},
+				"queryStringParameters": map[string]interface{}{"dryRun": "true"},
+				"headers":               map[string]interface{}{"x-tenant": "acme"},
+				"body":                  `{"sku": "ABC-1", "quantity": 2}`,
+			},
+			StatusCode: internalHTTP.StatusOK,
+			Out:        `{"quantity":2,"sku":"ABC-1"}`,
+		},
+		{
+			Name: "it should respond 400 for invalid params",
+			Event: map[string]interface{}{
+				"pathParameters":        map[string]interface{}{"id": "0"},
+				"queryStringParameters": map[string]interface{}{"dryRun": "maybe"},
+				"body":                  `{"sku": "ABC-1"}`,
+			},
+			StatusCode: internalHTTP.StatusBadRequest,
+			Out:        `{"detail":"Request does not match specification","errors":[{"field":"id","source":"path","path":"id","message":"must be at least 1"},{"field":"dryRun","source":"query","path":"dryRun","message":"must be a boolean"},{"field":"X-Tenant","source":"header","path":"X-Tenant","message":"is required"}],"status":400,"title":"Bad Request","type":"about:blank"}`,
+		},
+		{
+			Name: "it should respond 400 for invalid body",
+			Event: map[string]interface{}{
+				"pathParameters": map[string]interface{}{"id": "1"},
+				"headers":        map[string]interface{}{"X-Tenant": "acme"},
+				"body":           `{"quantity": 1.5, "tags": ["a", "b", 3]}`,
+			},
+			StatusCode: internalHTTP.StatusBadRequest,
+			Out:        `{"detail":"Request does not match specification","errors":[{"field":"sku","source":"body","path":"sku","message":"is required"},{"field":"quantity","source":"body","path":"quantity","message":"must be an integer"},{"field":"tags","source":"body","path":"tags","message":"must contain at most 2 items"},{"field":"tags","source":"body","path":"tags[2]","message":"must be a string"}],"status":400,"title":"Bad Request","type":"about:blank"}`,
+		},
+		{
+			Name: "it should respond 400 for missing body",
+			Event: map[string]interface{}{
+				"pathParameters": map[string]interface{}{"id": "1"},
+				"headers":        map[string]interface{}{"X-Tenant": "acme"},
+			},
+			StatusCode: internalHTTP.StatusBadRequest,
+			Out:        `{"detail":"Request does not match specification","errors":[{"field":"","source":"body","path":"","message":"is required"}],"status":400,"title":"Bad Request","type":"about:blank"}`,
+		},
+		{
+			Name: "it should respond 415 for undocumented media types",
+			Event: map[string]interface{}{
+				"pathParameters": map[string]interface{}{"id": "1"},
+				"headers":        map[string]interface{}{"X-Tenant": "acme", "Content-Type": "text/plain"},
+				"body":           `ABC-1`,
+			},
+			StatusCode: internalHTTP.StatusUnsupportedMediaType,
+			Out:        `{"detail":"Unsupported media type","status":415,"title":"Unsupported Media Type","type":"about:blank"}`,
+		},
+		{
+			Name: "it should respond 500 for responses violating the document",
+			Event: map[string]interface{}{
+				"pathParameters": map[string]interface{}{"id": "1"},
+				"headers":        map[string]interface{}{"X-Tenant": "acme"},
+				"body":           `{"sku": "ABC-1"}`,
+			},
+			Handler: func(i *http.Input) domain.Response {
+				return http.NewResponse(internalHTTP.StatusOK, map[string]interface{}{"sku": "abc"})
+			},
+			StatusCode: internalHTTP.StatusInternalServerError,
+			Out:        `{"status":500,"title":"Internal Server Error","type":"about:blank"}`,
+		},
+		{
+			Name: "it should respond 500 for undocumented statuses",
+			Event: map[string]interface{}{
+				"pathParameters": map[string]interface{}{"id": "1"},
+				"headers":        map[string]interface{}{"X-Tenant": "acme"},
+				"body":           `{"sku": "ABC-1"}`,
+			},
+			Handler: func(i *http.Input) domain.Response {
+				return http.NewResponse(internalHTTP.StatusAccepted, nil)
+			},
+			StatusCode: internalHTTP.StatusInternalServerError,
+			Out:        `{"status":500,"title":"Internal Server Error","type":"about:blank"}`,
+		},
+		{
+			Name: "it should accept documented error responses",
+			Event: map[string]interface{}{
+				"pathParameters": map[string]interface{}{"id": "1"},
+				"headers":        map[string]interface{}{"X-Tenant": "acme"},
+				"body":           `{"sku": "ABC-1"}`,
+			},
+			Handler: func(i *http.Input) domain.Response {
+				return http.Conflict("Order already exists")
+			},
+			StatusCode: internalHTTP.StatusConflict,
+			Out:        `{"detail":"Order already exists","status":409,"title":"Conflict","type":"about:blank"}`,
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			doc, err := openapi.Load([]byte(spec))
+			assert.Nil(t, err)
+			validator := openapi.NewValidator(doc)
+
+			handler := td.Handler
+			if handler == nil {
+				handler = func(i *http.Input) domain.Response {
+					var body map[string]interface{}
+					_ = i.ParseBody(&body)
+					return http.NewResponse(internalHTTP.StatusOK, body)
+				}
+			}
+			routes := http.Routes{
+				"/orders/{id}": {internalHTTP.MethodPut: http.Route{Handler: handler}},
+			}
+			td.Event["resource"] = "/orders/{id}"
+			td.Event["httpMethod"] = internalHTTP.MethodPut
+
+			// When
+			router := http.NewRouter(routes, nil, http.WithRequestValidator(validator), http.WithResponseValidator(validator))
+			res, err := router.Route(td.Event)
+
+			// Then
+			assert.Nil(t, err)
+			payload := res.Payload().(map[string]interface{})
+			assert.Equal(t, td.StatusCode, payload["statusCode"])
+			assert.Equal(t, td.Out, payload["body"])
+		})
+	}
+}
+
+const keywordSpec = `
+openapi: 3.1.0
+info:
+  title: Orders
+  version: 1.0.0
+paths:
+  /orders:
+    parameters:
+      - $ref: '#/components/parameters/tenant'
+    post:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: [integer, "null"]
+      requestBody:
+        $ref: '#/components/requestBodies/order'
+      responses:
+        "201":
+          $ref: '#/components/responses/created'
+        4XX:
+          $ref: '#/components/responses/problem'
+components:
+  parameters:
+    tenant:
+      name: X-Tenant
+      in: header
+      required: true
+      schema:
+        type: string
+  requestBodies:
+    order:
+      required: true
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/order'
+  responses:
+    created:
+      description: Created
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/order'
+    problem:
+      description: Client error
+      content:
+        application/problem+json:
+          schema:
+            type: object
+  schemas:
+    line:
+      type: object
+      properties:
+        sku:
+          type: string
+    order:
+      type: object
+      additionalProperties: false
+      properties:
+        note:
+          type: [string, "null"]
+        legacy:
+          type: string
+          nullable: true
+        discount:
+          oneOf:
+            - type: integer
+            - type: string
+        items:
+          type: array
+          items:
+            allOf:
+              - $ref: '#/components/schemas/line'
+              - required: [quantity]
+        metadata:
+          type: object
+          additionalProperties: true
+`
+
+func TestValidatorKeywords(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Event      map[string]interface{}
+		StatusCode int
+		Out        string
+	}{
+		{
+			Name: "it should succeed",
+			Event: map[string]interface{}{
+				"queryStringParameters": map[string]interface{}{"limit": "10"},
+				"headers":               map[string]interface{}{"X-Tenant": "acme"},
+				"body":                  `{"note": null, "legacy": null, "discount": "10%", "items": [{"sku": "ABC-1", "quantity": 1}], "metadata": {"source": "web"}}`,
+			},
+			StatusCode: internalHTTP.StatusCreated,
+			Out:        `{"note":"created"}`,
+		},
+		{
+			Name: "it should respond 400 for violated keywords",
+			Event: map[string]interface{}{
+				"queryStringParameters": map[string]interface{}{"limit": "ten"},
+				"body":                  `{"note": 5, "discount": true, "items": [{"sku": "ABC-1"}], "color": "red"}`,
+			},
+			StatusCode: internalHTTP.StatusBadRequest,
+			Out:        `{"detail":"Request does not match specification","errors":[{"field":"limit","source":"query","path":"limit","message":"must be an integer or null"},{"field":"X-Tenant","source":"header","path":"X-Tenant","message":"is required"},{"field":"color","source":"body","path":"color","message":"is not allowed"},{"field":"discount","source":"body","path":"discount","message":"must be an integer or a string"},{"field":"quantity","source":"body","path":"items[0].quantity","message":"is required"},{"field":"note","source":"body","path":"note","message":"must be a string or null"}],"status":400,"title":"Bad Request","type":"about:blank"}`,
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			doc, err := openapi.Load([]byte(keywordSpec))
+			assert.Nil(t, err)
+			validator := openapi.NewValidator(doc)
+
+			routes := http.Routes{
+				"/orders": {internalHTTP.MethodPost: http.Route{Handler: func(i *http.Input) domain.Response {
+					return http.NewResponse(internalHTTP.StatusCreated, map[string]interface{}{"note": "created"})
+				}}},
+			}
+			td.Event["resource"] = "/orders"
+			td.Event["httpMethod"] = internalHTTP.MethodPost
+
+			// When
+			router := http.NewRouter(routes, nil, http.WithRequestValidator(validator), http.WithResponseValidator(validator))
+			res, err := router.Route(td.Event)
+
+			// Then
+			assert.Nil(t, err)
+			payload := res.Payload().(map[string]interface{})
+			assert.Equal(t, td.StatusCode, payload["statusCode"])
+			assert.Equal(t, td.Out, payload["body"])
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	generated, _ := os.ReadFile(filepath.Join("testdata", "openapi.yaml"))
+	tests := []struct {
+		Name string
+		In   string
+		Err  bool
+	}{
+		{
+			Name: "it should load YAML",
+			In:   spec,
+		},
+		{
+			Name: "it should load generated documents",
+			In:   string(generated),
+		},
+		{
+			Name: "it should load JSON",
+			In:   `{"openapi": "3.0.3", "info": {"title": "Orders", "version": "1"}, "paths": {}}`,
+		},
+		{
+			Name: "it should load OpenAPI 3.1 keywords",
+			In:   keywordSpec,
+		},
+		{
+			Name: "it should fail for unresolved references",
+			In:   `{"openapi": "3.1.0", "paths": {"/orders": {"get": {"parameters": [{"$ref": "#/components/parameters/id"}]}}}}`,
+			Err:  true,
+		},
+		{
+			Name: "it should fail for path item references",
+			In:   `{"openapi": "3.1.0", "paths": {"/orders": {"$ref": "#/paths/~1users"}}}`,
+			Err:  true,
+		},
+		{
+			Name: "it should fail for invalid types",
+			In:   `{"openapi": "3.1.0", "components": {"schemas": {"id": {"type": 1}}}}`,
+			Err:  true,
+		},
+		{
+			Name: "it should fail for unsupported versions",
+			In:   `{"swagger": "2.0"}`,
+			Err:  true,
+		},
+		{
+			Name: "it should fail for invalid documents",
+			In:   "openapi: [",
+			Err:  true,
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// When
+			doc, err := openapi.Load([]byte(td.In))
+
+			// Then
+			assert.Equal(t, td.Err, err != nil)
+			assert.Equal(t, td.Err, doc == nil)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package redact
+
+import (
+	"testing"
+
+	"github.com/matthisstenius/lambda-router/v4/redact"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayload(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Redactor *redact.Redactor
+		Payload  interface{}
+		Out      interface{}
+	}{
+		{
+			Name:     "it should mask headers case-insensitively",
+			Redactor: &redact.Redactor{Headers: []string{"Authorization"}},
+			Payload: map[string]interface{}{
+				"headers": map[string]interface{}{
+					"authorization": "Bearer token",
+					"Content-Type":  "application/json",
+				},
+				"multiValueHeaders": map[string]interface{}{
+					"Authorization": []interface{}{"Bearer token"},
+				},
+			},
+			Out: map[string]interface{}{
+				"headers": map[string]interface{}{
+					"authorization": redact.Mask,
+					"Content-Type":  "application/json",
+				},
+				"multiValueHeaders": map[string]interface{}{
+					"Authorization": redact.Mask,
+				},
+			},
+		},
+		{
+			Name:     "it should mask fields at any depth",
+			Redactor: &redact.Redactor{Fields: []string{"claims"}, Mask: "***"},
+			Payload: map[string]interface{}{
+				"requestContext": map[string]interface{}{
+					"authorizer": map[string]interface{}{
+						"claims": map[string]interface{}{"sub": "12345"},
+					},
+				},
+			},
+			Out: map[string]interface{}{
+				"requestContext": map[string]interface{}{
+					"authorizer": map[string]interface{}{
+						"claims": "***",
+					},
+				},
+			},
+		},
+		{
+			Name:     "it should mask JSON paths in body",
+			Redactor: &redact.Redactor{BodyPaths: []string{"$.password", "$.cards[*].number"}},
+			Payload: map[string]interface{}{
+				"body": `{"cards":[{"number":"4111","type":"visa"}],"password":"secret","user":"test"}`,
+			},
+			Out: map[string]interface{}{
+				"body": `{"cards":[{"number":"[REDACTED]","type":"visa"}],"password":"[REDACTED]","user":"test"}`,
+			},
+		},
+		{
+			Name:     "it should leave none JSON body untouched",
+			Redactor: &redact.Redactor{BodyPaths: []string{"$.password"}},
+			Payload:  map[string]interface{}{"body": "plain text"},
+			Out:      map[string]interface{}{"body": "plain text"},
+		},
+		{
+			Name:     "it should truncate large values",
+			Redactor: &redact.Redactor{MaxLength: 5},
+			Payload:  map[string]interface{}{"body": "1234567890"},
+			Out:      map[string]interface{}{"body": "12345...[TRUNCATED 5 bytes]"},
+		},
+		{
+			Name:     "it should truncate on rune boundary",
+			Redactor: &redact.Redactor{MaxLength: 3},
+			Payload:  map[string]interface{}{"body": "åäöü"},
+			Out:      map[string]interface{}{"body": "å...[TRUNCATED 6 bytes]"},
+		},
+		{
+			Name:     "it should handle response payload headers",
+			Redactor: redact.Default(),
+			Payload: map[string]interface{}{
+				"statusCode": 200,
+				"headers":    map[string]string{"Set-Cookie": "session=1"},
+			},
+			Out: map[string]interface{}{
+				"statusCode": 200,
+				"headers":    map[string]interface{}{"Set-Cookie": redact.Mask},
+			},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// When
+			out := td.Redactor.Payload(td.Payload)
+
+			// Then
+			assert.Equal(t, td.Out, out)
+		})
+	}
+}
+
+func TestEventIsNotMutated(t *testing.T) {
+	// Given
+	evt := map[string]interface{}{
+		"headers": map[string]interface{}{"Authorization": "Bearer token"},
+		"body":    `{"password":"secret"}`,
+	}
+
+	// When
+	redact.Default().Event(evt)
+
+	// Then
+	assert.Equal(t, "Bearer token", evt["headers"].(map[string]interface{})["Authorization"])
+	assert.Equal(t, `{"password":"secret"}`, evt["body"])
+}
@@ -1,12 +1,22 @@
 package test
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	internalHTTP "net/http"
 	"testing"
 
 	"errors"
 	"github.com/matthisstenius/lambda-router/v4"
 	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/http"
+	"github.com/matthisstenius/lambda-router/v4/logging"
+	"github.com/matthisstenius/lambda-router/v4/metrics"
 	"github.com/matthisstenius/lambda-router/v4/mock"
+	"github.com/matthisstenius/lambda-router/v4/s3"
+	"github.com/matthisstenius/lambda-router/v4/schedule"
+	"github.com/matthisstenius/lambda-router/v4/sns"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -146,3 +156,305 @@ func TestStart(t *testing.T) {
 		})
 	}
 }
+
+func TestLogging(t *testing.T) {
+	// Given
+	logger := mock.NewLogger()
+	httpRouter := &mock.Router{
+		IsMatchFn: func(evt map[string]interface{}) bool {
+			return true
+		},
+		DispatchFn: func(evt map[string]interface{}) (domain.Response, error) {
+			return new(mock.Response), nil
+		},
+	}
+	event := router.NewEvent(&router.Config{HTTP: httpRouter, Logger: logger})
+
+	// When
+	_, err := event.Handle(map[string]interface{}{
+		"headers": map[string]interface{}{"Authorization": "Bearer token"},
+	})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []mock.LogEntry{
+		{
+			Level:   "info",
+			Message: "Incoming event",
+			Fields: domain.Fields{"event": map[string]interface{}{
+				"headers": map[string]interface{}{"Authorization": "[REDACTED]"},
+			}},
+		},
+		{
+			Level:   "info",
+			Message: "Outgoing response",
+			Fields:  domain.Fields{"response": "No payload"},
+		},
+	}, *logger.Entries)
+}
+
+func TestLoggingAuthorizerEvent(t *testing.T) {
+	// Given
+	logger := mock.NewLogger()
+	authorizerRouter := &mock.Router{
+		IsMatchFn: func(evt map[string]interface{}) bool {
+			return true
+		},
+		DispatchFn: func(evt map[string]interface{}) (domain.Response, error) {
+			return new(mock.Response), nil
+		},
+	}
+	event := router.NewEvent(&router.Config{Authorizer: authorizerRouter, Logger: logger})
+
+	// When
+	_, err := event.Handle(map[string]interface{}{
+		"type":           "REQUEST",
+		"routeArn":       "arn:aws:execute-api:eu-west-1:123:api/$default/GET/orders",
+		"identitySource": []interface{}{"Bearer secret"},
+		"headers":        map[string]interface{}{"authorization": "Bearer secret"},
+	})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, domain.Fields{"event": map[string]interface{}{
+		"type":           "REQUEST",
+		"routeArn":       "arn:aws:execute-api:eu-west-1:123:api/$default/GET/orders",
+		"identitySource": "[REDACTED]",
+		"headers":        map[string]interface{}{"authorization": "[REDACTED]"},
+	}}, (*logger.Entries)[0].Fields)
+}
+
+func TestRouterLogger(t *testing.T) {
+	tests := []struct {
+		Name         string
+		Options      []schedule.Option
+		ConfigLogged bool
+	}{
+		{
+			Name:         "it should use config logger for routers",
+			ConfigLogged: true,
+		},
+		{
+			Name:    "it should keep router logger",
+			Options: []schedule.Option{schedule.WithLogger(logging.Nop())},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			logger := mock.NewLogger()
+			scheduled := schedule.NewRouter(schedule.Routes{
+				"rule": schedule.Route{Handler: func() domain.Response { return schedule.NewResponse("done") }},
+			}, td.Options...)
+			event := router.NewEvent(&router.Config{Scheduled: scheduled, Logger: logger})
+
+			// When
+			_, err := event.Handle(map[string]interface{}{"eventSource": schedule.EventSource, "resource": "rule"})
+
+			// Then
+			assert.Nil(t, err)
+			logged := false
+			for _, entry := range *logger.Entries {
+				if entry.Message == "Router::Route() schedule handler responded" {
+					logged = true
+				}
+			}
+			assert.Equal(t, td.ConfigLogged, logged)
+		})
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	tests := []struct {
+		Name       string
+		StatusCode int
+		Out        map[string]interface{}
+	}{
+		{
+			Name:       "it should succeed",
+			StatusCode: internalHTTP.StatusOK,
+			Out: map[string]interface{}{
+				"Source":      "http",
+				"Route":       "GET /test/{id}",
+				"Invocations": float64(1),
+				"Errors":      float64(0),
+				"Status2xx":   float64(1),
+				"Orders":      float64(2),
+				"statusCode":  float64(200),
+			},
+		},
+		{
+			Name:       "it should count server errors",
+			StatusCode: internalHTTP.StatusInternalServerError,
+			Out: map[string]interface{}{
+				"Source":      "http",
+				"Route":       "GET /test/{id}",
+				"Invocations": float64(1),
+				"Errors":      float64(1),
+				"Status5xx":   float64(1),
+				"Orders":      float64(2),
+				"statusCode":  float64(500),
+			},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			var buf bytes.Buffer
+			routes := http.Routes{
+				"/test/{id}": {
+					internalHTTP.MethodGet: http.Route{
+						Handler: func(i *http.Input) domain.Response {
+							metrics.FromContext(i.Context()).Put("Orders", 2, metrics.UnitCount)
+							return http.NewResponse(td.StatusCode, "")
+						},
+					},
+				},
+			}
+			event := router.NewEvent(&router.Config{
+				HTTP:    http.NewRouter(routes, nil),
+				Logger:  logging.Nop(),
+				Metrics: &router.MetricsConfig{Namespace: "Test", Writer: &buf},
+			})
+
+			// When
+			_, err := event.Handle(map[string]interface{}{
+				"resource":       "/test/1",
+				"httpMethod":     internalHTTP.MethodGet,
+				"pathParameters": map[string]interface{}{"id": "1"},
+			})
+
+			// Then
+			assert.Nil(t, err)
+			var out map[string]interface{}
+			assert.Nil(t, json.Unmarshal(buf.Bytes(), &out))
+			assert.NotNil(t, out["_aws"])
+			assert.NotNil(t, out["Latency"])
+			delete(out, "_aws")
+			delete(out, "Latency")
+			assert.Equal(t, td.Out, out)
+		})
+	}
+}
+
+func TestRoutes(t *testing.T) {
+	// Given
+	access := &domain.Access{Roles: []string{"admin"}, Key: "cognito:groups"}
+	handler := func(i *http.Input) domain.Response { return nil }
+	event := router.NewEvent(&router.Config{
+		HTTP: http.NewRouter(http.Routes{
+			"/users/{id}": {
+				internalHTTP.MethodPut:    http.Route{Handler: handler, Access: access},
+				internalHTTP.MethodDelete: http.Route{Handler: handler},
+			},
+		}, nil),
+		SNS: sns.NewRouter(sns.Routes{
+			"arn:aws:sns:eu-west-1:123:orders": sns.Route{Handler: func(i *sns.Input) domain.Response { return nil }},
+		}),
+	})
+
+	// When
+	routes := event.Routes()
+
+	// Then
+	assert.Equal(t, []domain.RouteInfo{
+		{Source: domain.SourceHTTP, Key: "/users/{id}", Method: internalHTTP.MethodDelete},
+		{Source: domain.SourceHTTP, Key: "/users/{id}", Method: internalHTTP.MethodPut, Access: access},
+		{Source: domain.SourceSNS, Key: "arn:aws:sns:eu-west-1:123:orders"},
+	}, routes)
+}
+
+type invalidRequest struct {
+	Name     string `json:"name" validate:"required,short"`
+	Quantity int    `json:"quantity" validate:"min=one"`
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Config *router.Config
+		Error  error
+	}{
+		{
+			Name: "it should succeed",
+			Config: &router.Config{
+				HTTP: http.NewRouter(http.Routes{
+					"/users/{id}/files/{path+}": {
+						internalHTTP.MethodGet: http.Route{Handler: func(i *http.Input) domain.Response { return nil }},
+					},
+				}, nil),
+				S3: s3.NewRouter(s3.Routes{
+					"/uploads": s3.Route{Handler: func(i *s3.Input) domain.Response { return nil }},
+				}),
+			},
+		},
+		{
+			Name: "it should report misconfigured routes",
+			Config: &router.Config{
+				HTTP: http.NewRouter(http.Routes{
+					"/users/{id": {
+						"Get": http.Route{Middleware: []http.Middleware{nil}},
+					},
+					"/orders": {
+						internalHTTP.MethodPost: http.Route{
+							Handler: func(i *http.Input) domain.Response { return nil },
+							Request: invalidRequest{},
+						},
+					},
+					"/orders/{id}/{id}": {
+						internalHTTP.MethodGet: http.Route{
+							Handler:  func(i *http.Input) domain.Response { return nil },
+							Request:  "",
+							Produces: []string{"application/pdf", "application/problem+json"},
+						},
+					},
+					"/files/{path+}/meta": {
+						internalHTTP.MethodGet: http.Route{Handler: func(i *http.Input) domain.Response { return nil }},
+					},
+				}, []http.Middleware{nil}),
+				S3: s3.NewRouter(s3.Routes{
+					"uploads": s3.Route{},
+				}),
+			},
+			Error: errors.Join(
+				fmt.Errorf("http: %w", errors.Join(
+					errors.New("middleware 0 is nil"),
+					errors.New(`route /files/{path+}/meta: greedy path param "{path+}" must be the last segment`),
+					errors.New(`route POST /orders: invalidRequest.Name: unknown rule "short"`),
+					errors.New(`route POST /orders: invalidRequest.Quantity: invalid limit "one" for min`),
+					errors.New(`route /orders/{id}/{id}: duplicate path param "id"`),
+					errors.New("route GET /orders/{id}/{id}: request must be a struct, got string"),
+					errors.New("route GET /orders/{id}/{id}: encoder missing for produced media type application/pdf"),
+					errors.New("route GET /orders/{id}/{id}: produced media type application/problem+json is reserved for error responses"),
+					errors.New(`route /users/{id: unbalanced braces in segment "{id"`),
+					errors.New(`route Get /users/{id: invalid method "Get"`),
+					errors.New("route Get /users/{id: handler missing"),
+					errors.New("route Get /users/{id: middleware 0 is nil"),
+				)),
+				fmt.Errorf("s3: %w", errors.Join(
+					errors.New("route uploads: prefix must start with /"),
+					errors.New("route uploads: handler missing"),
+				)),
+			),
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			event := router.NewEvent(td.Config)
+
+			// When
+			err := event.Validate()
+
+			// Then
+			if td.Error == nil {
+				assert.Nil(t, err)
+				return
+			}
+			assert.EqualError(t, err, td.Error.Error())
+		})
+	}
+}
@@ -1,8 +1,11 @@
 package schedule
 
 import (
+	"context"
 	"errors"
 	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/metrics"
+	"github.com/matthisstenius/lambda-router/v4/mock"
 	"github.com/matthisstenius/lambda-router/v4/schedule"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -53,6 +56,39 @@ func TestRoute(t *testing.T) {
 	}
 }
 
+func TestRouteInput(t *testing.T) {
+	// Given
+	logger := mock.NewLogger()
+	m := metrics.New("App")
+	ctx := metrics.NewContext(context.Background(), m)
+	var rule string
+	routes := schedule.Routes{
+		"nightly": {
+			InputHandler: func(i *schedule.Input) domain.Response {
+				rule = i.Rule()
+				i.Metrics().Put("ReportsGenerated", 1, metrics.UnitCount)
+				i.Logger().Info("generating report", nil)
+				return schedule.NewResponse("done")
+			},
+		},
+	}
+
+	// When
+	router := schedule.NewRouter(routes, schedule.WithLogger(logger))
+	_, err := router.RouteContext(ctx, map[string]interface{}{"resource": "nightly", "id": "event-id"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "nightly", rule)
+	assert.Equal(t, mock.LogEntry{
+		Level:   "info",
+		Message: "generating report",
+		Fields:  domain.Fields{domain.CorrelationIDField: "event-id"},
+	}, (*logger.Entries)[0])
+	encoded, _ := m.MarshalJSON()
+	assert.Contains(t, string(encoded), `"ReportsGenerated":1`)
+}
+
 func TestIsMatch(t *testing.T) {
 	tests := []struct {
 		Name    string
@@ -0,0 +1,73 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/matthisstenius/lambda-router/v4/logging"
+	"github.com/matthisstenius/lambda-router/v4/schedule"
+	"github.com/stretchr/testify/assert"
+)
+
+type reportRequested struct {
+	ReportID string `json:"reportId"`
+}
+
+func TestTyped(t *testing.T) {
+	tests := []struct {
+		Name         string
+		Detail       interface{}
+		HandlerError error
+		Out          reportRequested
+		Called       bool
+		Error        error
+	}{
+		{
+			Name:   "it should succeed",
+			Detail: map[string]interface{}{"reportId": "1"},
+			Out:    reportRequested{ReportID: "1"},
+			Called: true,
+		},
+		{
+			Name:   "it should discard invalid detail",
+			Detail: map[string]interface{}{"reportId": 1},
+		},
+		{
+			Name:         "it should fail invocation on handler error",
+			Detail:       map[string]interface{}{"reportId": "1"},
+			HandlerError: errors.New("downstream unavailable"),
+			Out:          reportRequested{ReportID: "1"},
+			Called:       true,
+			Error:        errors.New("downstream unavailable"),
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			var out reportRequested
+			called := false
+			routes := schedule.Routes{
+				"nightly": schedule.Typed(func(ctx context.Context, detail reportRequested) error {
+					out, called = detail, true
+					return td.HandlerError
+				}),
+			}
+			event := map[string]interface{}{
+				"resource": "nightly",
+				"detail":   td.Detail,
+			}
+
+			// When
+			router := schedule.NewRouter(routes, schedule.WithLogger(logging.Nop()))
+			_, err := router.Route(event)
+
+			// Then
+			assert.Equal(t, td.Error, err)
+			assert.Equal(t, td.Called, called)
+			assert.Equal(t, td.Out, out)
+			assert.Equal(t, reportRequested{}, routes["nightly"].Detail)
+		})
+	}
+}
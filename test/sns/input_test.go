@@ -0,0 +1,67 @@
+package sns
+
+import (
+	"testing"
+
+	"github.com/matthisstenius/lambda-router/v4/sns"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCorrelationID(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Event map[string]interface{}
+		Out   string
+	}{
+		{
+			Name: "it should succeed with correlation message attribute",
+			Event: map[string]interface{}{
+				"Records": []interface{}{
+					map[string]interface{}{
+						"Sns": map[string]interface{}{
+							"MessageId": "message-id",
+							"MessageAttributes": map[string]interface{}{
+								"X-Correlation-Id": map[string]interface{}{
+									"Type":  "String",
+									"Value": "correlation-id",
+								},
+							},
+						},
+					},
+				},
+			},
+			Out: "correlation-id",
+		},
+		{
+			Name: "it should fall back to message id",
+			Event: map[string]interface{}{
+				"Records": []interface{}{
+					map[string]interface{}{
+						"Sns": map[string]interface{}{
+							"MessageId": "message-id",
+						},
+					},
+				},
+			},
+			Out: "message-id",
+		},
+		{
+			Name:  "it should handle missing records",
+			Event: map[string]interface{}{},
+			Out:   "",
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			input := sns.NewInput(td.Event)
+
+			// When
+			out := input.CorrelationID()
+
+			// Then
+			assert.Equal(t, td.Out, out)
+		})
+	}
+}
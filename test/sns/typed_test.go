@@ -0,0 +1,79 @@
+package sns
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/matthisstenius/lambda-router/v4/logging"
+	"github.com/matthisstenius/lambda-router/v4/sns"
+	"github.com/stretchr/testify/assert"
+)
+
+type orderPlaced struct {
+	OrderID string `json:"orderId"`
+}
+
+func TestTyped(t *testing.T) {
+	tests := []struct {
+		Name         string
+		Message      string
+		HandlerError error
+		Out          orderPlaced
+		Called       bool
+		Error        error
+	}{
+		{
+			Name:    "it should succeed",
+			Message: `{"orderId": "1"}`,
+			Out:     orderPlaced{OrderID: "1"},
+			Called:  true,
+		},
+		{
+			Name:    "it should discard invalid message",
+			Message: `{"orderId": 1}`,
+		},
+		{
+			Name:         "it should fail invocation on handler error",
+			Message:      `{"orderId": "1"}`,
+			HandlerError: errors.New("downstream unavailable"),
+			Out:          orderPlaced{OrderID: "1"},
+			Called:       true,
+			Error:        errors.New("downstream unavailable"),
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			var out orderPlaced
+			called := false
+			routes := sns.Routes{
+				"test:topic:arn": sns.Typed(func(ctx context.Context, msg orderPlaced) error {
+					out, called = msg, true
+					return td.HandlerError
+				}),
+			}
+			event := map[string]interface{}{
+				"Records": []interface{}{
+					map[string]interface{}{
+						"Sns": map[string]interface{}{
+							"TopicArn": "test:topic:arn",
+							"Message":  td.Message,
+						},
+					},
+				},
+			}
+
+			// When
+			router := sns.NewRouter(routes, sns.WithLogger(logging.Nop()))
+			_, err := router.Route(event)
+
+			// Then
+			assert.Equal(t, td.Error, err)
+			assert.Equal(t, td.Called, called)
+			assert.Equal(t, td.Out, out)
+			assert.Equal(t, orderPlaced{}, routes["test:topic:arn"].Message)
+		})
+	}
+}
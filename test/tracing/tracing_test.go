@@ -0,0 +1,169 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	internalHTTP "net/http"
+	"testing"
+
+	"github.com/matthisstenius/lambda-router/v4"
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/http"
+	"github.com/matthisstenius/lambda-router/v4/logging"
+	"github.com/matthisstenius/lambda-router/v4/sns"
+	"github.com/matthisstenius/lambda-router/v4/tracing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Env   string
+		Event map[string]interface{}
+		Out   tracing.Carrier
+	}{
+		{
+			Name:  "it should extract lambda trace id from environment",
+			Env:   "Root=1-5759e988-bd862e3fe1be46a994272793",
+			Event: map[string]interface{}{},
+			Out:   tracing.Carrier{tracing.AmznTraceID: "Root=1-5759e988-bd862e3fe1be46a994272793"},
+		},
+		{
+			Name: "it should extract traceparent header",
+			Event: map[string]interface{}{
+				"headers": map[string]interface{}{
+					"Traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+					"tracestate":  "vendor=value",
+				},
+			},
+			Out: tracing.Carrier{
+				tracing.TraceParent: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+				tracing.TraceState:  "vendor=value",
+			},
+		},
+		{
+			Name: "it should extract SQS AWSTraceHeader attribute",
+			Env:  "Root=1-lambda",
+			Event: map[string]interface{}{
+				"Records": []interface{}{
+					map[string]interface{}{
+						"attributes": map[string]interface{}{"AWSTraceHeader": "Root=1-message"},
+					},
+				},
+			},
+			Out: tracing.Carrier{tracing.AmznTraceID: "Root=1-message"},
+		},
+		{
+			Name: "it should extract SNS AWSTraceHeader message attribute",
+			Event: map[string]interface{}{
+				"Records": []interface{}{
+					map[string]interface{}{
+						"Sns": map[string]interface{}{
+							"MessageAttributes": map[string]interface{}{
+								"AWSTraceHeader": map[string]interface{}{"Type": "String", "Value": "Root=1-message"},
+							},
+						},
+					},
+				},
+			},
+			Out: tracing.Carrier{tracing.AmznTraceID: "Root=1-message"},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// Given
+			t.Setenv("_X_AMZN_TRACE_ID", td.Env)
+
+			// When
+			out := tracing.Extract(td.Event)
+
+			// Then
+			assert.Equal(t, td.Out, out)
+		})
+	}
+}
+
+func TestEventSpans(t *testing.T) {
+	// Given
+	recorder := tracing.NewRecorder()
+	routes := http.Routes{
+		"/test/{id}": {
+			internalHTTP.MethodGet: http.Route{
+				Handler: func(i *http.Input) domain.Response {
+					return http.NewResponse(internalHTTP.StatusOK, "")
+				},
+			},
+		},
+	}
+	event := router.NewEvent(&router.Config{
+		HTTP:   http.NewRouter(routes, nil),
+		Logger: logging.Nop(),
+		Tracer: recorder,
+	})
+
+	// When
+	_, err := event.HandleContext(context.Background(), map[string]interface{}{
+		"resource":       "/test/1",
+		"httpMethod":     internalHTTP.MethodGet,
+		"pathParameters": map[string]interface{}{"id": "1"},
+		"headers": map[string]interface{}{
+			"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		},
+	})
+
+	// Then
+	assert.Nil(t, err)
+	carrier := tracing.Carrier{tracing.TraceParent: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"}
+	assert.Equal(t, []tracing.RecordedSpan{
+		{
+			Name: "router.Event.Handle",
+			Attributes: tracing.Attributes{
+				tracing.AttributeSource:     domain.SourceHTTP,
+				tracing.AttributeRoute:      "GET /test/{id}",
+				tracing.AttributeStatusCode: internalHTTP.StatusOK,
+			},
+			Carrier: carrier,
+			Ended:   true,
+		},
+		{
+			Name:   "GET /test/{id}",
+			Parent: "router.Event.Handle",
+			Attributes: tracing.Attributes{
+				tracing.AttributeSource:     domain.SourceHTTP,
+				tracing.AttributeRoute:      "GET /test/{id}",
+				tracing.AttributeStatusCode: internalHTTP.StatusOK,
+			},
+			Carrier: carrier,
+			Ended:   true,
+		},
+	}, recorder.Spans())
+}
+
+func TestEventSpanError(t *testing.T) {
+	// Given
+	recorder := tracing.NewRecorder()
+	event := router.NewEvent(&router.Config{
+		SNS:    sns.NewRouter(sns.Routes{}),
+		Logger: logging.Nop(),
+		Tracer: recorder,
+	})
+
+	// When
+	_, err := event.Handle(map[string]interface{}{
+		"Records": []interface{}{
+			map[string]interface{}{
+				"EventSource": sns.EventSource,
+				"Sns":         map[string]interface{}{"TopicArn": "arn:aws:sns:eu-west-1:123:test-topic"},
+			},
+		},
+	})
+
+	// Then
+	assert.Equal(t, errors.New("handler func missing"), err)
+	spans := recorder.Spans()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "test-topic", spans[1].Name)
+	assert.Equal(t, []error{err}, spans[1].Errors)
+	assert.Equal(t, []error{err}, spans[0].Errors)
+}
@@ -0,0 +1,160 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+	"github.com/matthisstenius/lambda-router/v4/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+type item struct {
+	SKU      string `json:"sku" validate:"required,regex=^[A-Z]{3}-[0-9]+$"`
+	Quantity int    `json:"quantity" validate:"min=1,max=10"`
+}
+
+type order struct {
+	Tenant string   `header:"X-Tenant" validate:"required"`
+	ID     string   `path:"id" validate:"uuid"`
+	Limit  int      `query:"limit" validate:"max=100"`
+	Email  string   `json:"email" validate:"required,email"`
+	Status string   `json:"status" validate:"oneof=open closed"`
+	Code   string   `json:"code" validate:"len=4"`
+	Note   *string  `json:"note" validate:"max=5"`
+	Tags   []string `json:"tags" validate:"max=2,dive,min=2"`
+	Items  []item   `json:"items" validate:"required"`
+}
+
+func TestStruct(t *testing.T) {
+	note := "too long note"
+	tests := []struct {
+		Name  string
+		Value interface{}
+		Error error
+	}{
+		{
+			Name: "it should succeed",
+			Value: &order{
+				Tenant: "acme",
+				ID:     "8a6e0804-2bd0-4672-b79d-d97027f9071a",
+				Limit:  10,
+				Email:  "john@example.com",
+				Status: "open",
+				Code:   "ABCD",
+				Tags:   []string{"ab", "cd"},
+				Items:  []item{{SKU: "ABC-1", Quantity: 1}},
+			},
+		},
+		{
+			Name: "it should handle invalid fields",
+			Value: order{
+				ID:     "not-a-uuid",
+				Limit:  500,
+				Email:  "john",
+				Status: "pending",
+				Code:   "ABC",
+				Note:   &note,
+				Tags:   []string{"a"},
+				Items:  []item{{SKU: "abc", Quantity: 0}},
+			},
+			Error: &validate.Error{
+				Message: "validation failed",
+				Fields: []domain.FieldError{
+					{Field: "X-Tenant", Source: "header", Path: "X-Tenant", Message: "is required"},
+					{Field: "id", Source: "path", Path: "id", Message: "must be a valid UUID"},
+					{Field: "limit", Source: "query", Path: "limit", Message: "must be at most 100"},
+					{Field: "email", Source: "body", Path: "email", Message: "must be a valid email address"},
+					{Field: "status", Source: "body", Path: "status", Message: "must be one of open, closed"},
+					{Field: "code", Source: "body", Path: "code", Message: "must be exactly 4 characters"},
+					{Field: "note", Source: "body", Path: "note", Message: "must be at most 5 characters"},
+					{Field: "tags", Source: "body", Path: "tags[0]", Message: "must be at least 2 characters"},
+					{Field: "sku", Source: "body", Path: "items[0].sku", Message: "must match ^[A-Z]{3}-[0-9]+$"},
+					{Field: "quantity", Source: "body", Path: "items[0].quantity", Message: "must be at least 1"},
+				},
+			},
+		},
+		{
+			Name:  "it should handle missing slice",
+			Value: &order{Tenant: "acme", Email: "john@example.com", Tags: []string{"ab", "cd", "ef"}},
+			Error: &validate.Error{
+				Message: "validation failed",
+				Fields: []domain.FieldError{
+					{Field: "tags", Source: "body", Path: "tags", Message: "must contain at most 2 items"},
+					{Field: "items", Source: "body", Path: "items", Message: "is required"},
+				},
+			},
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// When
+			err := validate.Struct(td.Value)
+
+			// Then
+			assert.Equal(t, td.Error, err)
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	type unknownRule struct {
+		Name string `json:"name" validate:"required,short"`
+	}
+	type invalidLimit struct {
+		Name string `json:"name" validate:"max=ten"`
+	}
+	type invalidPattern struct {
+		Name string `json:"name" validate:"regex=^[a-z"`
+	}
+	type nested struct {
+		Items []invalidLimit `json:"items" validate:"required"`
+	}
+
+	tests := []struct {
+		Name  string
+		Value interface{}
+		Err   string
+	}{
+		{
+			Name:  "it should succeed",
+			Value: &order{},
+		},
+		{
+			Name:  "it should fail for unknown rules",
+			Value: unknownRule{},
+			Err:   `unknownRule.Name: unknown rule "short"`,
+		},
+		{
+			Name:  "it should fail for invalid limits",
+			Value: &invalidLimit{},
+			Err:   `invalidLimit.Name: invalid limit "ten" for max`,
+		},
+		{
+			Name:  "it should fail for invalid patterns",
+			Value: invalidPattern{},
+			Err:   "invalidPattern.Name: invalid regex \"^[a-z\": error parsing regexp: missing closing ]: `[a-z`",
+		},
+		{
+			Name:  "it should fail for nested structs",
+			Value: nested{},
+			Err:   `invalidLimit.Name: invalid limit "ten" for max`,
+		},
+	}
+
+	for _, td := range tests {
+		t.Run(td.Name, func(t *testing.T) {
+			// When
+			checkErr := validate.Check(td.Value)
+			structErr := validate.Struct(td.Value)
+
+			// Then
+			if td.Err == "" {
+				assert.Nil(t, checkErr)
+				return
+			}
+			assert.EqualError(t, checkErr, td.Err)
+			assert.EqualError(t, structErr, td.Err)
+		})
+	}
+}
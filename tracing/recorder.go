@@ -0,0 +1,81 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+)
+
+// RecordedSpan captured by Recorder
+type RecordedSpan struct {
+	Name       string
+	Parent     string
+	Attributes Attributes
+	Carrier    Carrier
+	Errors     []error
+	Ended      bool
+}
+
+// Recorder in-memory Tracer capturing spans, intended for tests
+type Recorder struct {
+	spans []*RecordedSpan
+	mu    sync.Mutex
+}
+
+// NewRecorder initializer
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+type recorderKey struct{}
+
+// Start span as child of any span in ctx
+func (r *Recorder) Start(ctx context.Context, name string, attrs Attributes) (context.Context, Span) {
+	span := &RecordedSpan{Name: name, Attributes: Attributes{}, Carrier: CarrierFromContext(ctx)}
+	if parent, ok := ctx.Value(recorderKey{}).(*RecordedSpan); ok {
+		span.Parent = parent.Name
+	}
+	for k, v := range attrs {
+		span.Attributes[k] = v
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = append(r.spans, span)
+	return context.WithValue(ctx, recorderKey{}, span), &recordedSpan{span: span, mu: &r.mu}
+}
+
+// Spans recorded so far in start order
+func (r *Recorder) Spans() []RecordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]RecordedSpan, 0, len(r.spans))
+	for _, s := range r.spans {
+		out = append(out, *s)
+	}
+	return out
+}
+
+type recordedSpan struct {
+	span *RecordedSpan
+	mu   *sync.Mutex
+}
+
+func (s *recordedSpan) SetAttributes(attrs Attributes) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k, v := range attrs {
+		s.span.Attributes[k] = v
+	}
+}
+
+func (s *recordedSpan) RecordError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.span.Errors = append(s.span.Errors, err)
+}
+
+func (s *recordedSpan) End() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.span.Ended = true
+}
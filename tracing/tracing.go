@@ -0,0 +1,128 @@
+// Package tracing instruments routing with spans behind a small Tracer interface.
+// Plug in OpenTelemetry or X-Ray by implementing Tracer and reading the propagated
+// trace context with CarrierFromContext when starting the root span.
+package tracing
+
+import (
+	"context"
+	"os"
+	"strings"
+)
+
+// Span attribute keys set by router.Event
+const (
+	AttributeSource     = "event.source"
+	AttributeRoute      = "route.key"
+	AttributeStatusCode = "http.response.status_code"
+)
+
+// Propagation keys in Carrier
+const (
+	TraceParent = "traceparent"
+	TraceState  = "tracestate"
+	AmznTraceID = "X-Amzn-Trace-Id"
+)
+
+// Attributes for spans
+type Attributes map[string]interface{}
+
+// Tracer starting spans
+type Tracer interface {
+	Start(ctx context.Context, name string, attrs Attributes) (context.Context, Span)
+}
+
+// Span around a unit of work
+type Span interface {
+	SetAttributes(attrs Attributes)
+	RecordError(err error)
+	End()
+}
+
+// Carrier with trace context propagated by the event, keyed by TraceParent, TraceState and AmznTraceID
+type Carrier map[string]string
+
+type carrierKey struct{}
+
+// ContextWithCarrier attaches propagated trace context for tracers to continue from
+func ContextWithCarrier(ctx context.Context, carrier Carrier) context.Context {
+	return context.WithValue(ctx, carrierKey{}, carrier)
+}
+
+// CarrierFromContext propagated trace context, empty when none was extracted
+func CarrierFromContext(ctx context.Context) Carrier {
+	if carrier, ok := ctx.Value(carrierKey{}).(Carrier); ok {
+		return carrier
+	}
+	return Carrier{}
+}
+
+// Extract trace context from _X_AMZN_TRACE_ID, HTTP traceparent headers and
+// AWSTraceHeader attributes of SNS/SQS records
+func Extract(evt map[string]interface{}) Carrier {
+	carrier := Carrier{}
+	if id := os.Getenv("_X_AMZN_TRACE_ID"); id != "" {
+		carrier[AmznTraceID] = id
+	}
+
+	if headers, ok := evt["headers"].(map[string]interface{}); ok {
+		for k, v := range headers {
+			value, ok := v.(string)
+			if !ok {
+				continue
+			}
+			switch {
+			case strings.EqualFold(k, TraceParent):
+				carrier[TraceParent] = value
+			case strings.EqualFold(k, TraceState):
+				carrier[TraceState] = value
+			case strings.EqualFold(k, AmznTraceID):
+				carrier[AmznTraceID] = value
+			}
+		}
+	}
+
+	records, ok := evt["Records"].([]interface{})
+	if !ok || len(records) == 0 {
+		return carrier
+	}
+	record, _ := records[0].(map[string]interface{})
+	if attributes, ok := record["attributes"].(map[string]interface{}); ok {
+		if value, ok := attributes["AWSTraceHeader"].(string); ok {
+			carrier[AmznTraceID] = value
+		}
+	}
+	if message, ok := record["Sns"].(map[string]interface{}); ok {
+		attributes, _ := message["MessageAttributes"].(map[string]interface{})
+		for k, v := range attributes {
+			attribute, _ := v.(map[string]interface{})
+			value, ok := attribute["Value"].(string)
+			if !ok {
+				continue
+			}
+			switch {
+			case k == "AWSTraceHeader":
+				carrier[AmznTraceID] = value
+			case strings.EqualFold(k, TraceParent):
+				carrier[TraceParent] = value
+			}
+		}
+	}
+	return carrier
+}
+
+// Nop Tracer discarding all spans
+func Nop() Tracer {
+	return nop{}
+}
+
+type nop struct{}
+
+func (nop) Start(ctx context.Context, name string, attrs Attributes) (context.Context, Span) {
+	return ctx, nop{}
+}
+
+func (nop) SetAttributes(attrs Attributes) {}
+
+func (nop) RecordError(err error) {}
+
+func (nop) End() {}
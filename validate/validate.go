@@ -0,0 +1,400 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/matthisstenius/lambda-router/v4/domain"
+)
+
+// Tag holding comma separated rules, e.g. validate:"required,min=1,max=100"
+const Tag = "validate"
+
+var (
+	uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	// Parsed rules per struct type
+	compiled sync.Map
+	// Tag errors per struct type including nested types
+	checked sync.Map
+)
+
+// Error with every invalid field, responded as 422 by the HTTP router
+type Error struct {
+	Message string              `json:"message"`
+	Fields  []domain.FieldError `json:"fields"`
+}
+
+func (e *Error) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		parts = append(parts, fmt.Sprintf("%s %s", f.Path, f.Message))
+	}
+	return fmt.Sprintf("%s: %s", e.Message, strings.Join(parts, "; "))
+}
+
+// Struct validates fields of struct v against their validate tags. Supported rules are
+// required, min=n, max=n, len=n, oneof=a b c, email, uuid and regex=pattern, which must
+// come last as the pattern may contain commas. Nested structs and slice elements are
+// validated recursively, rules following dive apply to each slice element. Invalid tags
+// are reported as returned by Check rather than as *Error
+func Struct(v interface{}) error {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("validate target must be a struct, got %T", v)
+	}
+	if err := checkType(value.Type()); err != nil {
+		return err
+	}
+
+	err := &Error{Message: "validation failed"}
+	validateStruct(value, "", "", err)
+	if len(err.Fields) > 0 {
+		return err
+	}
+	return nil
+}
+
+// Check validate tags of struct v and its nested structs, reporting unknown rules,
+// invalid limits and invalid patterns. Tags are parsed once per type
+func Check(v interface{}) error {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return fmt.Errorf("validate target must be a struct, got %T", v)
+	}
+	return checkType(t)
+}
+
+func checkType(t reflect.Type) error {
+	if err, ok := checked.Load(t); ok {
+		if err == nil {
+			return nil
+		}
+		return err.(error)
+	}
+	err := checkNested(t, map[reflect.Type]bool{})
+	checked.Store(t, err)
+	return err
+}
+
+func checkNested(t reflect.Type, seen map[reflect.Type]bool) error {
+	if seen[t] {
+		return nil
+	}
+	seen[t] = true
+
+	errs := []error{compile(t).err}
+	for n := 0; n < t.NumField(); n++ {
+		ft := t.Field(n).Type
+		for ft.Kind() == reflect.Ptr || ft.Kind() == reflect.Slice || ft.Kind() == reflect.Array || ft.Kind() == reflect.Map {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct {
+			errs = append(errs, checkNested(ft, seen))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Parsed rules of a struct type's fields
+type structRules struct {
+	fields []fieldRules
+	err    error
+}
+
+type fieldRules struct {
+	index     int
+	embedded  bool
+	rules     []rule
+	elemRules []rule
+}
+
+func compile(t reflect.Type) *structRules {
+	if c, ok := compiled.Load(t); ok {
+		return c.(*structRules)
+	}
+
+	sr := &structRules{}
+	var errs []error
+	for n := 0; n < t.NumField(); n++ {
+		field := t.Field(n)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			sr.fields = append(sr.fields, fieldRules{index: n, embedded: true})
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		rules, elemRules, err := parseRules(field.Tag.Get(Tag))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err))
+		}
+		sr.fields = append(sr.fields, fieldRules{index: n, rules: rules, elemRules: elemRules})
+	}
+	sr.err = errors.Join(errs...)
+	compiled.Store(t, sr)
+	return sr
+}
+
+func validateStruct(v reflect.Value, path string, source string, err *Error) {
+	t := v.Type()
+	for _, f := range compile(t).fields {
+		if f.embedded {
+			validateStruct(v.Field(f.index), path, source, err)
+			continue
+		}
+
+		name, fieldSource := fieldName(t.Field(f.index), source)
+		if name == "-" {
+			continue
+		}
+		fieldPath := name
+		if path != "" {
+			fieldPath = path + "." + name
+		}
+
+		loc := location{field: name, path: fieldPath, source: fieldSource}
+		validateValue(v.Field(f.index), f.rules, f.elemRules, loc, err)
+	}
+}
+
+type location struct {
+	field  string
+	path   string
+	source string
+}
+
+func validateValue(v reflect.Value, rules []rule, elemRules []rule, loc location, err *Error) {
+	for _, r := range rules {
+		if msg := r.check(v); msg != "" {
+			err.Fields = append(err.Fields, domain.FieldError{
+				Field:   loc.field,
+				Source:  loc.source,
+				Path:    loc.path,
+				Message: msg,
+			})
+			return
+		}
+	}
+
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		validateStruct(v, loc.path, loc.source, err)
+	case reflect.Slice, reflect.Array:
+		for n := 0; n < v.Len(); n++ {
+			elem := loc
+			elem.path = fmt.Sprintf("%s[%d]", loc.path, n)
+			validateValue(v.Index(n), elemRules, nil, elem, err)
+		}
+	}
+}
+
+// Field name as sent by the client and its source, nested fields inherit the source
+func fieldName(field reflect.StructField, source string) (string, string) {
+	if source == "" {
+		for _, s := range []string{"path", "query", "header", "form"} {
+			if name, ok := field.Tag.Lookup(s); ok && name != "-" {
+				if name == "" {
+					name = field.Name
+				}
+				return name, s
+			}
+		}
+		source = "body"
+	}
+	if tag, ok := field.Tag.Lookup("json"); ok {
+		if name := strings.Split(tag, ",")[0]; name != "" {
+			return name, source
+		}
+	}
+	return field.Name, source
+}
+
+type rule struct {
+	name    string
+	param   string
+	limit   float64
+	options []string
+	pattern *regexp.Regexp
+}
+
+// Splits tag into rules for the field and, after dive, rules for slice elements
+func parseRules(tag string) ([]rule, []rule, error) {
+	var rules, elemRules []rule
+	var errs []error
+	target := &rules
+	for tag != "" {
+		var part string
+		if strings.HasPrefix(tag, "regex=") {
+			part, tag = tag, ""
+		} else if idx := strings.Index(tag, ","); idx >= 0 {
+			part, tag = tag[:idx], tag[idx+1:]
+		} else {
+			part, tag = tag, ""
+		}
+
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if part == "dive" {
+			target = &elemRules
+			continue
+		}
+		r := rule{name: part}
+		if idx := strings.Index(part, "="); idx >= 0 {
+			r = rule{name: part[:idx], param: part[idx+1:]}
+		}
+		if err := r.parse(); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		*target = append(*target, r)
+	}
+	return rules, elemRules, errors.Join(errs...)
+}
+
+// Parses rule param, reporting unknown rules and invalid params
+func (r *rule) parse() error {
+	var err error
+	switch r.name {
+	case "required", "email", "uuid":
+	case "min", "max", "len":
+		if r.limit, err = strconv.ParseFloat(r.param, 64); err != nil {
+			return fmt.Errorf("invalid limit %q for %s", r.param, r.name)
+		}
+	case "oneof":
+		if r.options = strings.Fields(r.param); len(r.options) == 0 {
+			return errors.New("oneof requires at least one option")
+		}
+	case "regex":
+		if r.pattern, err = regexp.Compile(r.param); err != nil {
+			return fmt.Errorf("invalid regex %q: %v", r.param, err)
+		}
+	default:
+		return fmt.Errorf("unknown rule %q", r.name)
+	}
+	return nil
+}
+
+// Checks value against rule, returning a message when invalid. Rules other than
+// required are skipped for nil pointers and empty strings
+func (r rule) check(v reflect.Value) string {
+	if r.name == "required" {
+		if isEmpty(v) {
+			return "is required"
+		}
+		return ""
+	}
+
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.String && v.Len() == 0 {
+		return ""
+	}
+
+	switch r.name {
+	case "min":
+		return r.compare(v, func(a, b float64) bool { return a >= b }, "at least")
+	case "max":
+		return r.compare(v, func(a, b float64) bool { return a <= b }, "at most")
+	case "len":
+		return r.compare(v, func(a, b float64) bool { return a == b }, "exactly")
+	case "oneof":
+		value := fmt.Sprint(v.Interface())
+		for _, o := range r.options {
+			if o == value {
+				return ""
+			}
+		}
+		return fmt.Sprintf("must be one of %s", strings.Join(r.options, ", "))
+	case "email":
+		if s, ok := stringValue(v); ok {
+			if addr, err := mail.ParseAddress(s); err != nil || addr.Address != s {
+				return "must be a valid email address"
+			}
+		}
+	case "uuid":
+		if s, ok := stringValue(v); ok && !uuidPattern.MatchString(s) {
+			return "must be a valid UUID"
+		}
+	case "regex":
+		if s, ok := stringValue(v); ok && !r.pattern.MatchString(s) {
+			return fmt.Sprintf("must match %s", r.param)
+		}
+	}
+	return ""
+}
+
+// Compares numbers by value and strings, slices and maps by length
+func (r rule) compare(v reflect.Value, ok func(a, b float64) bool, qualifier string) string {
+	limit, param := r.limit, r.param
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !ok(float64(v.Int()), limit) {
+			return fmt.Sprintf("must be %s %s", qualifier, param)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if !ok(float64(v.Uint()), limit) {
+			return fmt.Sprintf("must be %s %s", qualifier, param)
+		}
+	case reflect.Float32, reflect.Float64:
+		if !ok(v.Float(), limit) {
+			return fmt.Sprintf("must be %s %s", qualifier, param)
+		}
+	case reflect.String:
+		if !ok(float64(utf8.RuneCountInString(v.String())), limit) {
+			return fmt.Sprintf("must be %s %s characters", qualifier, param)
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if !ok(float64(v.Len()), limit) {
+			return fmt.Sprintf("must contain %s %s items", qualifier, param)
+		}
+	}
+	return ""
+}
+
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	case reflect.Slice, reflect.Map, reflect.String:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+func stringValue(v reflect.Value) (string, bool) {
+	if v.Kind() != reflect.String {
+		return "", false
+	}
+	return v.String(), true
+}